@@ -219,6 +219,17 @@ func handleBotCommand(ctx context.Context, a client.BotAgent, session *botSessio
 		}
 		log.Infof("Attached blobs: %s", strings.Join(session.blobIDs, ", "))
 		return true, nil
+	case "/sync-blobs":
+		blobs, err := a.ListBlobs(ctx, session.channelID)
+		if err != nil {
+			return true, err
+		}
+		session.blobIDs = make([]string, 0, len(blobs))
+		for _, blob := range blobs {
+			session.blobIDs = append(session.blobIDs, blob.BlobId)
+		}
+		log.Infof("Restored %d blob(s) from channel %s", len(session.blobIDs), session.channelID)
+		return true, nil
 	case "/clear-blobs":
 		session.blobIDs = nil
 		log.Info("Attached blobs cleared")
@@ -236,6 +247,8 @@ func handleBotCommand(ctx context.Context, a client.BotAgent, session *botSessio
 		if len(parts) > 1 {
 			msg = strings.TrimSpace(strings.TrimPrefix(input, "/reset"))
 		}
+		// Attachments belong to the conversation being reset.
+		session.blobIDs = nil
 		return true, sendBotMessage(ctx, a, session, msg, models.PostBackActionResetChanel)
 	default:
 		return true, fmt.Errorf("unknown command: %s (use /help)", cmd)
@@ -305,55 +318,39 @@ func sendByREST(ctx context.Context, a client.BotAgent, msg *models.GenericBotMe
 }
 
 func sendBySSE(ctx context.Context, a client.BotAgent, msg *models.GenericBotMessage) error {
-	stream, err := a.NewStreamer(ctx, msg)
-	if err != nil {
-		return err
-	}
-	defer stream.Close()
-
-	for stream.Next() {
-		e := stream.Current()
-		if *verbose {
-			log.Debugf("event=%+v", e)
-		}
-
-		switch e.EventType {
-		case models.SseEventTypeMessageDelta:
-			if e.Fact.MessageDelta != nil && e.Fact.MessageDelta.Message.Text != "" {
-				fmt.Print(e.Fact.MessageDelta.Message.Text)
+	return client.StreamMessage(ctx, a, msg, client.EventHandlers{
+		OnEvent: func(e *models.GenericBotSseEvent) error {
+			if *verbose {
+				log.Debugf("event=%+v", e)
 			}
-		case models.SseEventTypeMessageComplete:
-			fmt.Println()
-			if e.Fact.MessageComplete != nil && e.Fact.MessageComplete.Message.Template != nil {
-				log.Debugf("template=%s", e.Fact.MessageComplete.Message.Template.Type)
+			return nil
+		},
+		OnMessageDelta: func(m *models.BufferedMessage) error {
+			if m.Text != "" {
+				fmt.Print(m.Text)
 			}
-		case models.SseEventTypeRunError:
-			if e.Fact.RunError != nil {
-				return fmt.Errorf("run error: %s", e.Fact.RunError.Error.Message)
+			return nil
+		},
+		OnMessageComplete: func(m *models.BufferedMessage) error {
+			fmt.Println()
+			if m.Template != nil {
+				log.Debugf("template=%s", m.Template.Type)
 			}
-		}
-	}
-
-	if err := stream.Err(); err != nil {
-		return err
-	}
-	return nil
+			return nil
+		},
+		OnRunError: func(detail *models.ErrorDetail) error {
+			return fmt.Errorf("run error: %s", detail.Message)
+		},
+	})
 }
 
 func uploadBlob(ctx context.Context, a client.BotAgent, channelID, filePath, mimeType string) (*models.Blob, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
-
-	filename := filepath.Base(filePath)
 	var mime *string
 	if mimeType != "" {
 		mime = &mimeType
 	}
 
-	return a.UploadBlob(ctx, channelID, file, filename, mime)
+	return a.UploadBlobFile(ctx, channelID, filePath, mime)
 }
 
 func runFunctionOnce(ctx context.Context, a client.FunctionAgent, payload map[string]interface{}) error {
@@ -439,9 +436,10 @@ func printBotHelp() {
 	fmt.Println("  /debug on|off              Toggle debug for REST /message")
 	fmt.Println("  /blob <path> [mime]        Upload blob and attach to conversation")
 	fmt.Println("  /blobs                     Show attached blob IDs")
+	fmt.Println("  /sync-blobs                Restore attached blob IDs from the server")
 	fmt.Println("  /clear-blobs               Clear attached blob IDs")
 	fmt.Println("  /channel [id]              Show or switch channel")
-	fmt.Println("  /reset [text]              Send RESET_CHANNEL message")
+	fmt.Println("  /reset [text]              Send RESET_CHANNEL message and clear attached blobs")
 	fmt.Println("  <any text>                 Send normal message")
 }
 
@@ -5,6 +5,7 @@ go 1.22.2
 require (
 	github.com/sirupsen/logrus v1.9.4
 	github.com/tmaxmax/go-sse v0.11.0
+	go.uber.org/goleak v1.3.0
 )
 
 require golang.org/x/sys v0.13.0 // indirect
@@ -10,14 +10,32 @@ import (
 // BotAgent handles conversational APIs (message / sse / blob).
 type BotAgent interface {
 	NewStreamer(ctx context.Context, message *models.GenericBotMessage) (BotProviderStreamer, error)
+	NewConversationStreamer(ctx context.Context, message *models.GenericBotMessage) (ConversationStreamer, error)
 	SendMessage(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (*models.GenericBotReply, error)
+	ReadMessages(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (MessageReader, error)
+	GetChannelHistory(ctx context.Context, customChannelID string, limit int, before *string) ([]models.BufferedMessage, error)
 	UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error)
+	UploadBlobWithStats(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, UploadStats, error)
+	UploadBlobs(ctx context.Context, customChannelID string, files []BlobUpload) ([]models.Blob, error)
+	UploadBlobFile(ctx context.Context, customChannelID, path string, mime *string) (*models.Blob, error)
+	ListBlobs(ctx context.Context, customChannelID string) ([]models.Blob, error)
+	DownloadReplyAttachments(ctx context.Context, reply *models.GenericBotReply) ([]DownloadedBlob, error)
+	CancelProcess(ctx context.Context, requestID, processID string) error
+	CancelRun(ctx context.Context, customChannelID, requestID string) error
+	VerifyAPIKey(ctx context.Context) (bool, error)
+	Ping(ctx context.Context) error
+	RunWithSink(ctx context.Context, message *models.GenericBotMessage, sink EventSink) error
+	StreamWithPersistence(ctx context.Context, message *models.GenericBotMessage, persist PersistFunc) (PersistingStreamer, error)
+	AttachChannel(channelID string) *Channel
 }
 
 // FunctionAgent handles trigger APIs (json / form).
 type FunctionAgent interface {
 	TriggerJSON(ctx context.Context, payload map[string]interface{}) (interface{}, error)
+	TriggerJSONStream(ctx context.Context, payload map[string]interface{}) (BotProviderStreamer, error)
 	TriggerForm(ctx context.Context, payload map[string]interface{}, reader io.Reader, filename string, mime *string) (interface{}, error)
+	VerifyAPIKey(ctx context.Context) (bool, error)
+	Ping(ctx context.Context) error
 }
 
 type botAgent struct {
@@ -43,6 +61,12 @@ func NewBotAgentWithConfig(config *BotProviderConfig) BotAgent {
 	return &botAgent{client: NewBotProviderClientWithConfig(config)}
 }
 
+// NewBotAgentFromClient creates a BotAgent on top of client, e.g. a
+// ContextClient or a test double.
+func NewBotAgentFromClient(client Client) BotAgent {
+	return &botAgent{client: client}
+}
+
 // NewFunctionAgent creates a FunctionAgent that hides the underlying Client.
 func NewFunctionAgent(edgeServerHost, namespace, botProviderName, botProviderAPIKey string) FunctionAgent {
 	return NewFunctionAgentWithConfig(&BotProviderConfig{
@@ -58,22 +82,84 @@ func NewFunctionAgentWithConfig(config *BotProviderConfig) FunctionAgent {
 	return &functionAgent{client: NewBotProviderClientWithConfig(config)}
 }
 
+// NewFunctionAgentFromClient creates a FunctionAgent on top of client, e.g.
+// a ContextClient or a test double.
+func NewFunctionAgentFromClient(client Client) FunctionAgent {
+	return &functionAgent{client: client}
+}
+
 func (a *botAgent) NewStreamer(ctx context.Context, message *models.GenericBotMessage) (BotProviderStreamer, error) {
 	return a.client.NewStreamer(ctx, message)
 }
 
+func (a *botAgent) NewConversationStreamer(ctx context.Context, message *models.GenericBotMessage) (ConversationStreamer, error) {
+	return a.client.NewConversationStreamer(ctx, message)
+}
+
 func (a *botAgent) SendMessage(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (*models.GenericBotReply, error) {
 	return a.client.SendMessage(ctx, message, isDebug)
 }
 
+func (a *botAgent) ReadMessages(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (MessageReader, error) {
+	return a.client.ReadMessages(ctx, message, isDebug)
+}
+
+func (a *botAgent) GetChannelHistory(ctx context.Context, customChannelID string, limit int, before *string) ([]models.BufferedMessage, error) {
+	return a.client.GetChannelHistory(ctx, customChannelID, limit, before)
+}
+
 func (a *botAgent) UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error) {
 	return a.client.UploadBlob(ctx, customChannelID, reader, filename, mime)
 }
 
+func (a *botAgent) UploadBlobWithStats(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, UploadStats, error) {
+	return a.client.UploadBlobWithStats(ctx, customChannelID, reader, filename, mime)
+}
+
+func (a *botAgent) UploadBlobs(ctx context.Context, customChannelID string, files []BlobUpload) ([]models.Blob, error) {
+	return a.client.UploadBlobs(ctx, customChannelID, files)
+}
+
+func (a *botAgent) ListBlobs(ctx context.Context, customChannelID string) ([]models.Blob, error) {
+	return a.client.ListBlobs(ctx, customChannelID)
+}
+
+func (a *botAgent) DownloadReplyAttachments(ctx context.Context, reply *models.GenericBotReply) ([]DownloadedBlob, error) {
+	return a.client.DownloadReplyAttachments(ctx, reply)
+}
+
+func (a *botAgent) CancelProcess(ctx context.Context, requestID, processID string) error {
+	return a.client.CancelProcess(ctx, requestID, processID)
+}
+
+func (a *botAgent) CancelRun(ctx context.Context, customChannelID, requestID string) error {
+	return a.client.CancelRun(ctx, customChannelID, requestID)
+}
+
+func (a *botAgent) VerifyAPIKey(ctx context.Context) (bool, error) {
+	return a.client.VerifyAPIKey(ctx)
+}
+
+func (a *botAgent) Ping(ctx context.Context) error {
+	return a.client.Ping(ctx)
+}
+
 func (a *functionAgent) TriggerJSON(ctx context.Context, payload map[string]interface{}) (interface{}, error) {
 	return a.client.TriggerJSON(ctx, payload)
 }
 
+func (a *functionAgent) TriggerJSONStream(ctx context.Context, payload map[string]interface{}) (BotProviderStreamer, error) {
+	return a.client.TriggerJSONStream(ctx, payload)
+}
+
 func (a *functionAgent) TriggerForm(ctx context.Context, payload map[string]interface{}, reader io.Reader, filename string, mime *string) (interface{}, error) {
 	return a.client.TriggerForm(ctx, payload, reader, filename, mime)
 }
+
+func (a *functionAgent) VerifyAPIKey(ctx context.Context) (bool, error) {
+	return a.client.VerifyAPIKey(ctx)
+}
+
+func (a *functionAgent) Ping(ctx context.Context) error {
+	return a.client.Ping(ctx)
+}
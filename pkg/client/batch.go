@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+type jsonTriggerClient interface {
+	TriggerJSON(ctx context.Context, payload map[string]interface{}) (interface{}, error)
+}
+
+// TriggerResult is the outcome of one TriggerJSON call of a batch.
+type TriggerResult struct {
+	// Index is the position of the payload in the batch.
+	Index int
+	Value interface{}
+	Err   error
+}
+
+// TriggerJSONBatch runs TriggerJSON for every payload through a FunctionAgent
+// or Client, with at most concurrency calls in flight (at least one). Results
+// are returned in input order with per-call errors in TriggerResult.Err. When
+// ctx is done, payloads not yet started fail with the context error, which is
+// also returned.
+func TriggerJSONBatch(ctx context.Context, client jsonTriggerClient, payloads []map[string]interface{}, concurrency int) ([]TriggerResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]TriggerResult, len(payloads))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, payload := range payloads {
+		results[i].Index = i
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(payloads); j++ {
+				results[j] = TriggerResult{Index: j, Err: ctx.Err()}
+			}
+			wg.Wait()
+			return results, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(i int, payload map[string]interface{}) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Value, results[i].Err = client.TriggerJSON(ctx, payload)
+		}(i, payload)
+	}
+
+	wg.Wait()
+	return results, ctx.Err()
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// BlobCache remembers uploaded blobs by channel and content hash. It is safe
+// for concurrent use; callers decide its lifetime and can Clear it at any time.
+type BlobCache struct {
+	mu    sync.Mutex
+	blobs map[string]models.Blob
+}
+
+// NewBlobCache creates an empty BlobCache.
+func NewBlobCache() *BlobCache {
+	return &BlobCache{blobs: make(map[string]models.Blob)}
+}
+
+// Get returns the blob uploaded to channelID with the given sha256 hex hash.
+func (c *BlobCache) Get(channelID, hash string) (*models.Blob, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	blob, ok := c.blobs[blobCacheKey(channelID, hash)]
+	if !ok {
+		return nil, false
+	}
+	return &blob, true
+}
+
+// Put records blob as the upload of content with the given hash to channelID.
+func (c *BlobCache) Put(channelID, hash string, blob models.Blob) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blobs[blobCacheKey(channelID, hash)] = blob
+}
+
+// Len returns the number of cached blobs.
+func (c *BlobCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.blobs)
+}
+
+// Clear drops every cached blob.
+func (c *BlobCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blobs = make(map[string]models.Blob)
+}
+
+func blobCacheKey(channelID, hash string) string {
+	return channelID + "\x00" + hash
+}
+
+type blobUploadClient interface {
+	UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error)
+}
+
+// BlobUploader uploads blobs through a BotAgent or Client, reusing the blob
+// of an earlier upload with identical content to the same channel instead of
+// sending the bytes again.
+type BlobUploader struct {
+	client blobUploadClient
+	cache  *BlobCache
+}
+
+// NewBlobUploader creates a BlobUploader backed by client and cache. A nil
+// cache gets a fresh one.
+func NewBlobUploader(client blobUploadClient, cache *BlobCache) *BlobUploader {
+	if cache == nil {
+		cache = NewBlobCache()
+	}
+	return &BlobUploader{client: client, cache: cache}
+}
+
+// Cache returns the cache used by the uploader.
+func (u *BlobUploader) Cache() *BlobCache {
+	return u.cache
+}
+
+// UploadBlob uploads reader unless identical content was already uploaded to
+// customChannelID. Deduplication needs to read the content twice, so it only
+// applies to readers implementing io.Seeker; other readers are always
+// uploaded.
+func (u *BlobUploader) UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error) {
+	seeker, ok := reader.(io.ReadSeeker)
+	if !ok {
+		return u.client.UploadBlob(ctx, customChannelID, reader, filename, mime)
+	}
+
+	hash, err := hashAndRewind(seeker)
+	if err != nil {
+		return nil, err
+	}
+
+	if blob, ok := u.cache.Get(customChannelID, hash); ok {
+		return blob, nil
+	}
+
+	blob, err := u.client.UploadBlob(ctx, customChannelID, seeker, filename, mime)
+	if err != nil {
+		return nil, err
+	}
+	u.cache.Put(customChannelID, hash, *blob)
+	return blob, nil
+}
+
+// hashAndRewind returns the sha256 of the rest of r and seeks back to where
+// reading started.
+func hashAndRewind(r io.ReadSeeker) (string, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", fmt.Errorf("failed to seek blob content: %w", err)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("failed to hash blob content: %w", err)
+	}
+
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind blob content: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
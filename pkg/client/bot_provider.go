@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,7 +10,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
-	"net/url"
+	"time"
 
 	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
 )
@@ -29,111 +30,125 @@ func (c *BotProviderClient) SendMessage(ctx context.Context, message *models.Gen
 	if message == nil {
 		return nil, fmt.Errorf("message cannot be nil")
 	}
+	reply, err := c.sendMessage(ctx, message, isDebug)
+	if err != nil && reply == nil {
+		return nil, queueInOutbox(ctx, c.config, message, isDebug, err)
+	}
+	return reply, err
+}
+
+func (c *BotProviderClient) sendMessage(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (_ *models.GenericBotReply, err error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.config.MessageTimeout)
+	defer cancel()
+
+	if !isOutboxReplay(ctx) {
+		if err := prepareMessage(c.config, message); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx, requestOptionsFromContext(ctx).Priority); err != nil {
+			return nil, requestError(ctx, "failed to send message", err)
+		}
+	}
 
-	u := fmt.Sprintf("%s/ns/%s/bot-provider/%s/message",
-		c.config.EdgeServerHost,
-		url.PathEscape(c.config.Namespace),
-		url.PathEscape(c.config.BotProviderName),
-	)
+	ctx, hook := startRequest(ctx, c.config, HookEndpointMessage)
+	defer func() { hook.done(err) }()
 
+	suffix := "message"
 	if isDebug {
-		u = fmt.Sprintf("%s?is_debug=true", u)
+		suffix = "message?is_debug=true"
 	}
 
-	body, err := json.Marshal(message)
+	body, err := json.Marshal(outgoingMessage(c.config, message))
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	resp, err := c.doJSONWithFailover(ctx, suffix, defaultJSONContentType, body, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, requestError(ctx, "failed to send message", err)
 	}
+	defer resp.Body.Close()
+	hook.status = resp.StatusCode
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-KEY", c.config.BotProviderApiKey)
-
-	resp, err := c.config.HTTPClient.Do(req)
+	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send message: %w", err)
+		return nil, requestError(ctx, "failed to read response body", err)
 	}
-	defer resp.Body.Close()
 
-	respBytes, err := io.ReadAll(resp.Body)
+	payload, err := decodeResponse[models.GenericBotReply](c.config, "send message", resp, respBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
-	var payload ApiResponse[models.GenericBotReply]
-	if err := json.Unmarshal(respBytes, &payload); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	for i := range payload.Data.Messages {
+		guardMessageText(&payload.Data.Messages[i], c.config.MaxMessageTextBytes, c.config.OnMessageTextTruncated)
 	}
 
-	if resp.StatusCode != http.StatusOK || !payload.IsSuccess {
-		return nil, fmt.Errorf("send message failed (%d): %s", resp.StatusCode, responseError(payload.Error, payload.ErrorCode))
+	if c.config.ErrorOnEmptyReply && len(payload.Data.Messages) == 0 {
+		return &payload.Data, ErrEmptyReply
 	}
 
 	return &payload.Data, nil
 }
 
-func (c *BotProviderClient) TriggerJSON(ctx context.Context, payload map[string]interface{}) (interface{}, error) {
-	u := fmt.Sprintf("%s/ns/%s/bot-provider/%s/json",
-		c.config.EdgeServerHost,
-		url.PathEscape(c.config.Namespace),
-		url.PathEscape(c.config.BotProviderName),
-	)
+func (c *BotProviderClient) TriggerJSON(ctx context.Context, payload map[string]interface{}) (_ interface{}, err error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.config.TriggerTimeout)
+	defer cancel()
 
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal json payload: %w", err)
-	}
+	ctx, hook := startRequest(ctx, c.config, HookEndpointJSON)
+	defer func() { hook.done(err) }()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	opts := requestOptionsFromContext(ctx)
+	contentType, err := opts.jsonContentType()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-KEY", c.config.BotProviderApiKey)
-
-	resp, err := c.config.HTTPClient.Do(req)
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to trigger json api: %w", err)
+		return nil, fmt.Errorf("failed to marshal json payload: %w", err)
 	}
-	defer resp.Body.Close()
 
-	respBytes, err := io.ReadAll(resp.Body)
+	resp, err := c.doJSONWithFailover(ctx, "json", contentType, body, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, requestError(ctx, "failed to trigger json api", err)
 	}
+	defer resp.Body.Close()
+	hook.status = resp.StatusCode
 
-	var wrapper ApiResponse[json.RawMessage]
-	if err := json.Unmarshal(respBytes, &wrapper); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	return decodeTriggerResponse(ctx, c.config, "trigger json", resp)
+}
 
-	if resp.StatusCode != http.StatusOK || !wrapper.IsSuccess {
-		return nil, fmt.Errorf("trigger json failed (%d): %s", resp.StatusCode, responseError(wrapper.Error, wrapper.ErrorCode))
+// TriggerJSONStream triggers the JSON API like TriggerJSON, but streams the
+// workflow's events from the json/sse endpoint as they happen instead of
+// waiting for the result. The stream behaves like one from NewStreamer; it
+// always uses SSE, whatever Transport is configured.
+func (c *BotProviderClient) TriggerJSONStream(ctx context.Context, payload map[string]interface{}) (BotProviderStreamer, error) {
+	opts := requestOptionsFromContext(ctx)
+	contentType, err := opts.jsonContentType()
+	if err != nil {
+		return nil, err
 	}
 
-	if len(wrapper.Data) == 0 || string(wrapper.Data) == "null" {
-		return nil, nil
-	}
+	return newSSEStream(ctx, c.config, sseRequest{
+		path:        "json/sse",
+		endpoint:    HookEndpointJSONSSE,
+		payload:     payload,
+		contentType: contentType,
+	})
+}
 
-	var result interface{}
-	if err := json.Unmarshal(wrapper.Data, &result); err != nil {
-		return nil, fmt.Errorf("failed to decode response data: %w", err)
-	}
+func (c *BotProviderClient) TriggerForm(ctx context.Context, payload map[string]interface{}, reader io.Reader, filename string, mime *string) (_ interface{}, err error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.config.TriggerTimeout)
+	defer cancel()
 
-	return result, nil
-}
+	ctx, hook := startRequest(ctx, c.config, HookEndpointForm)
+	defer func() { hook.done(err) }()
 
-func (c *BotProviderClient) TriggerForm(ctx context.Context, payload map[string]interface{}, reader io.Reader, filename string, mime *string) (interface{}, error) {
-	u := fmt.Sprintf("%s/ns/%s/bot-provider/%s/form",
-		c.config.EdgeServerHost,
-		url.PathEscape(c.config.Namespace),
-		url.PathEscape(c.config.BotProviderName),
-	)
+	u := c.botProviderURL(c.config.EdgeServerHost, "form")
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
@@ -141,18 +156,47 @@ func (c *BotProviderClient) TriggerForm(ctx context.Context, payload map[string]
 	}
 
 	pr, pw := io.Pipe()
-	writer := multipart.NewWriter(pw)
+	// Once the call returns the writer goroutine must not be left blocked on
+	// a pipe nobody reads, e.g. when the transport gave up on the body early.
+	defer pr.Close()
+	// A cancelled request waits for the transport to stop reading the body,
+	// which a stalled reader would otherwise hold up.
+	stop := context.AfterFunc(ctx, func() { _ = pr.CloseWithError(ctx.Err()) })
+	defer stop()
+
+	// The multipart body is gzipped on its way into the pipe, so the file is
+	// still streamed rather than buffered to be compressed.
+	var zw *gzip.Writer
+	var body io.Writer = pw
+	if c.config.FormRequestCompression {
+		zw = gzip.NewWriter(pw)
+		body = zw
+	}
+	writer := multipart.NewWriter(body)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, pr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	applyRequestOverrides(req, c.config)
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if zw != nil {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 	req.Header.Set("X-API-KEY", c.config.BotProviderApiKey)
+	applyHeaders(req, c.config, requestOptionsFromContext(ctx))
 
 	go func() {
 		defer pw.Close()
+		defer func() {
+			if zw == nil {
+				return
+			}
+			if closeErr := zw.Close(); closeErr != nil {
+				_ = pw.CloseWithError(fmt.Errorf("failed to close gzip writer: %w", closeErr))
+			}
+		}()
 		defer func() {
 			if closeErr := writer.Close(); closeErr != nil {
 				_ = pw.CloseWithError(fmt.Errorf("failed to close multipart writer: %w", closeErr))
@@ -168,13 +212,15 @@ func (c *BotProviderClient) TriggerForm(ctx context.Context, payload map[string]
 			return
 		}
 
+		file, contentType, err := fileContentType(reader, mime)
+		if err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+
 		header := make(textproto.MIMEHeader)
 		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
-		if mime != nil && *mime != "" {
-			header.Set("Content-Type", *mime)
-		} else {
-			header.Set("Content-Type", "application/octet-stream")
-		}
+		header.Set("Content-Type", contentType)
 
 		part, err := writer.CreatePart(header)
 		if err != nil {
@@ -182,7 +228,7 @@ func (c *BotProviderClient) TriggerForm(ctx context.Context, payload map[string]
 			return
 		}
 
-		if _, err := io.Copy(part, reader); err != nil {
+		if _, err := io.Copy(part, &contextReader{ctx: ctx, r: file}); err != nil {
 			_ = pw.CloseWithError(fmt.Errorf("failed to copy file data: %w", err))
 			return
 		}
@@ -190,53 +236,73 @@ func (c *BotProviderClient) TriggerForm(ctx context.Context, payload map[string]
 
 	resp, err := c.config.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to trigger form api: %w", err)
+		return nil, requestError(ctx, "failed to trigger form api", err)
 	}
 	defer resp.Body.Close()
+	hook.status = resp.StatusCode
 
-	respBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var wrapper ApiResponse[json.RawMessage]
-	if err := json.Unmarshal(respBytes, &wrapper); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	return decodeTriggerResponse(ctx, c.config, "trigger form", resp)
+}
 
-	if resp.StatusCode != http.StatusOK || !wrapper.IsSuccess {
-		return nil, fmt.Errorf("trigger form failed (%d): %s", resp.StatusCode, responseError(wrapper.Error, wrapper.ErrorCode))
+// UploadBlob uploads the content of reader to customChannelID. Without a mime
+// type the type is detected from the content, so images and documents are
+// classified correctly by the EdgeServer. reader is streamed from a separate
+// goroutine, which stops once the call has returned or ctx is done; only a
+// Read of reader that never returns can hold it. The call itself returns
+// when ctx is done, even while such a Read is blocked.
+func (c *BotProviderClient) UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error) {
+	blobs, err := c.uploadBlobs(ctx, customChannelID, []BlobUpload{{Reader: reader, Filename: filename, Mime: mime}}, nil)
+	if err != nil {
+		return nil, err
 	}
+	return &blobs[0], nil
+}
 
-	if len(wrapper.Data) == 0 || string(wrapper.Data) == "null" {
-		return nil, nil
+// UploadBlobWithStats is UploadBlob that also reports how the transfer went.
+// The stats are returned even when the upload fails.
+func (c *BotProviderClient) UploadBlobWithStats(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, UploadStats, error) {
+	meter := &transferMeter{}
+	start := time.Now()
+	blobs, err := c.uploadBlobs(ctx, customChannelID, []BlobUpload{{Reader: reader, Filename: filename, Mime: mime}}, meter)
+	if err != nil {
+		return nil, meter.stats(time.Since(start)), err
 	}
+	return &blobs[0], meter.stats(time.Since(start)), nil
+}
 
-	var result interface{}
-	if err := json.Unmarshal(wrapper.Data, &result); err != nil {
-		return nil, fmt.Errorf("failed to decode response data: %w", err)
-	}
+// uploadBlobs uploads files as the parts of one multipart body and returns
+// their blobs in the order of files.
+func (c *BotProviderClient) uploadBlobs(ctx context.Context, customChannelID string, files []BlobUpload, meter *transferMeter) (_ []models.Blob, err error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.config.UploadTimeout)
+	defer cancel()
 
-	return result, nil
-}
+	ctx, hook := startRequest(ctx, c.config, HookEndpointBlob)
+	defer func() { hook.done(err) }()
 
-func (c *BotProviderClient) UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error) {
-	u := fmt.Sprintf("%s/ns/%s/bot-provider/%s/blob",
-		c.config.EdgeServerHost,
-		url.PathEscape(c.config.Namespace),
-		url.PathEscape(c.config.BotProviderName),
-	)
+	u := c.botProviderURL(c.config.EdgeServerHost, "blob")
 
 	pr, pw := io.Pipe()
+	// See TriggerForm.
+	defer pr.Close()
+	stop := context.AfterFunc(ctx, func() { _ = pr.CloseWithError(ctx.Err()) })
+	defer stop()
 	writer := multipart.NewWriter(pw)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, pr)
+	var body io.Reader = pr
+	if meter != nil {
+		meter.r = pr
+		body = meter
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	applyRequestOverrides(req, c.config)
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("X-API-KEY", c.config.BotProviderApiKey)
+	applyHeaders(req, c.config, requestOptionsFromContext(ctx))
 
 	go func() {
 		defer pw.Close()
@@ -246,67 +312,223 @@ func (c *BotProviderClient) UploadBlob(ctx context.Context, customChannelID stri
 			}
 		}()
 
-		if err := writer.WriteField("customChannelId", customChannelID); err != nil {
+		if err := writer.WriteField("customChannelId", outgoingChannelID(c.config, customChannelID)); err != nil {
 			_ = pw.CloseWithError(fmt.Errorf("failed to write customChannelId: %w", err))
 			return
 		}
 
-		header := make(textproto.MIMEHeader)
-		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
-		if mime != nil && *mime != "" {
-			header.Set("Content-Type", *mime)
-		} else {
-			header.Set("Content-Type", "application/octet-stream")
-		}
+		for _, f := range files {
+			file, contentType, err := fileContentType(f.Reader, f.Mime)
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
 
-		part, err := writer.CreatePart(header)
-		if err != nil {
-			_ = pw.CloseWithError(fmt.Errorf("failed to create multipart part: %w", err))
-			return
-		}
+			header := make(textproto.MIMEHeader)
+			header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, f.Filename))
+			header.Set("Content-Type", contentType)
 
-		if _, err := io.Copy(part, reader); err != nil {
-			_ = pw.CloseWithError(fmt.Errorf("failed to copy file data: %w", err))
-			return
+			part, err := writer.CreatePart(header)
+			if err != nil {
+				_ = pw.CloseWithError(fmt.Errorf("failed to create multipart part: %w", err))
+				return
+			}
+
+			if _, err := io.Copy(part, &contextReader{ctx: ctx, r: file}); err != nil {
+				_ = pw.CloseWithError(fmt.Errorf("failed to copy file data of %s: %w", f.Filename, err))
+				return
+			}
 		}
 	}()
 
 	resp, err := c.config.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload blob: %w", err)
+		return nil, requestError(ctx, "failed to upload blob", err)
 	}
 	defer resp.Body.Close()
+	hook.status = resp.StatusCode
 
 	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var payload ApiResponse[[]models.Blob]
-	if err := json.Unmarshal(respBytes, &payload); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, requestError(ctx, "failed to read response body", err)
 	}
 
-	if resp.StatusCode != http.StatusOK || !payload.IsSuccess {
-		return nil, fmt.Errorf("upload blob failed (%d): %s", resp.StatusCode, responseError(payload.Error, payload.ErrorCode))
+	payload, err := decodeResponse[[]models.Blob](c.config, "upload blob", resp, respBytes)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(payload.Data) == 0 {
 		return nil, fmt.Errorf("upload blob succeeded but no blob metadata returned")
 	}
+	if len(payload.Data) < len(files) {
+		return nil, fmt.Errorf("upload blob returned %d blobs for %d files", len(payload.Data), len(files))
+	}
+
+	return orderBlobs(files, payload.Data), nil
+}
+
+// newJSONRequest builds a POST request for a JSON body sent as contentType.
+// When request compression is enabled and the body exceeds the threshold, the
+// body is gzip-encoded first. The request is always built from an in-memory reader,
+// so GetBody replays the encoded bytes rather than the original payload.
+func (c *BotProviderClient) newJSONRequest(ctx context.Context, u, contentType string, body []byte) (*http.Request, error) {
+	compressed := false
+	if c.config.RequestCompression && len(body) > c.compressionThreshold() {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+		body = buf.Bytes()
+		compressed = true
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	applyRequestOverrides(req, c.config)
+
+	req.Header.Set("Content-Type", contentType)
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	return req, nil
+}
+
+func (c *BotProviderClient) compressionThreshold() int {
+	if c.config.RequestCompressionThreshold > 0 {
+		return c.config.RequestCompressionThreshold
+	}
+	return defaultCompressionThreshold
+}
+
+// decodeTriggerResponse decodes the envelope of a trigger response straight
+// from the body through a context-aware reader, so a cancelled context stops a
+// large decode between reads instead of letting it run to completion. Only
+// the bodies of failed statuses are buffered, to be reported in the *APIError.
+func decodeTriggerResponse(ctx context.Context, config *BotProviderConfig, operation string, resp *http.Response) (interface{}, error) {
+	if resp.StatusCode != http.StatusOK {
+		respBytes, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, requestError(ctx, "failed to read response body", err)
+		}
+		_, err = decodeResponse[json.RawMessage](config, operation, resp, respBytes)
+		return nil, err
+	}
+
+	// The start of the body is kept for the *APIError of an envelope that
+	// reports a failure or cannot be decoded.
+	head := &headBuffer{limit: errorBodyLimit(config) + 1}
+	dec := json.NewDecoder(io.TeeReader(&contextReader{ctx: ctx, r: resp.Body}, head))
+
+	var payload ApiResponse[interface{}]
+	if err := dec.Decode(&payload); err != nil {
+		if ctx.Err() != nil {
+			return nil, requestError(ctx, "failed to decode response", err)
+		}
+		apiErr := newAPIError(config, operation, resp, head.Bytes())
+		apiErr.Err = err
+		return nil, apiErr
+	}
+
+	if !payload.IsSuccess {
+		apiErr := newAPIError(config, operation, resp, head.Bytes())
+		if payload.Error != nil {
+			apiErr.ErrorMessage = *payload.Error
+		}
+		if payload.ErrorCode != nil {
+			apiErr.ErrorCode = *payload.ErrorCode
+		}
+		return nil, apiErr
+	}
+
+	return payload.Data, nil
+}
+
+// headBuffer keeps the first limit bytes written to it and discards the rest.
+type headBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// contextReader fails reads once ctx is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// decodeResponse decodes an API envelope, returning an *APIError carrying the
+// raw body when the status or envelope reports a failure or the body cannot be
+// decoded.
+func decodeResponse[T any](config *BotProviderConfig, operation string, resp *http.Response, body []byte) (*ApiResponse[T], error) {
+	var payload ApiResponse[T]
+	if err := json.Unmarshal(body, &payload); err != nil {
+		apiErr := newAPIError(config, operation, resp, body)
+		apiErr.Err = err
+		return nil, apiErr
+	}
 
-	return &payload.Data[0], nil
+	if resp.StatusCode != http.StatusOK || !payload.IsSuccess {
+		apiErr := newAPIError(config, operation, resp, body)
+		if payload.Error != nil {
+			apiErr.ErrorMessage = *payload.Error
+		}
+		if payload.ErrorCode != nil {
+			apiErr.ErrorCode = *payload.ErrorCode
+		}
+		return nil, apiErr
+	}
+
+	return &payload, nil
 }
 
-func responseError(errMsg, errCode *string) string {
-	if errMsg == nil && errCode == nil {
-		return "unknown error"
+func newAPIError(config *BotProviderConfig, operation string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{Operation: operation, StatusCode: resp.StatusCode}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.Endpoint = resp.Request.URL.Path
 	}
-	if errMsg != nil && errCode != nil {
-		return fmt.Sprintf("%s (%s)", *errMsg, *errCode)
+
+	limit := errorBodyLimit(config)
+	if limit < 0 {
+		return apiErr
+	}
+	if len(body) > limit {
+		body = body[:limit]
+		apiErr.BodyTruncated = true
 	}
-	if errMsg != nil {
-		return *errMsg
+	apiErr.Body = string(body)
+	return apiErr
+}
+
+// errorBodyLimit is the number of body bytes kept in an *APIError; a negative
+// limit keeps none.
+func errorBodyLimit(config *BotProviderConfig) int {
+	if config.MaxErrorBodyBytes == 0 {
+		return defaultMaxErrorBodyBytes
 	}
-	return *errCode
+	return config.MaxErrorBodyBytes
 }
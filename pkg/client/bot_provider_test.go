@@ -0,0 +1,330 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.uber.org/goleak"
+)
+
+// blockingReader blocks every Read until release is called, then reports
+// EOF. reading is closed once the first Read started.
+type blockingReader struct {
+	reading     chan struct{}
+	released    chan struct{}
+	readingOnce sync.Once
+	releaseOnce sync.Once
+}
+
+func newBlockingReader() *blockingReader {
+	return &blockingReader{reading: make(chan struct{}), released: make(chan struct{})}
+}
+
+func (r *blockingReader) Read(p []byte) (int, error) {
+	r.readingOnce.Do(func() { close(r.reading) })
+	<-r.released
+	return 0, io.EOF
+}
+
+func (r *blockingReader) release() {
+	r.releaseOnce.Do(func() { close(r.released) })
+}
+
+// newTestClient returns a client of the EdgeServer at url with its own
+// transport, whose idle connections are closed at the end of the test.
+func newTestClient(t *testing.T, url string) client.Client {
+	t.Helper()
+	transport := &http.Transport{}
+	t.Cleanup(transport.CloseIdleConnections)
+	return client.NewBotProviderClientWithConfig(&client.BotProviderConfig{
+		EdgeServerHost:    url,
+		Namespace:         "default",
+		BotProviderName:   "test-bot",
+		BotProviderApiKey: "test-key",
+		HTTPClient:        &http.Client{Transport: transport},
+	})
+}
+
+// multipartCalls are the calls whose request body is written by a goroutine.
+var multipartCalls = []struct {
+	name string
+	call func(ctx context.Context, c client.Client, reader io.Reader) error
+}{
+	{"UploadBlob", func(ctx context.Context, c client.Client, reader io.Reader) error {
+		mime := "text/plain"
+		_, err := c.UploadBlob(ctx, "channel-1", reader, "file.txt", &mime)
+		return err
+	}},
+	{"TriggerForm", func(ctx context.Context, c client.Client, reader io.Reader) error {
+		mime := "text/plain"
+		_, err := c.TriggerForm(ctx, map[string]interface{}{"key": "value"}, reader, "file.txt", &mime)
+		return err
+	}},
+}
+
+func TestMultipartWriterStopsWhenCancelledMidUpload(t *testing.T) {
+	for _, tc := range multipartCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+			}))
+			defer srv.Close()
+			c := newTestClient(t, srv.URL)
+
+			reader := newBlockingReader()
+			defer reader.release()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				<-reader.reading
+				cancel()
+			}()
+
+			err := tc.call(ctx, c, reader)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected error wrapping context.Canceled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMultipartWriterStopsWithCancelledContext(t *testing.T) {
+	for _, tc := range multipartCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			var called atomic.Bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called.Store(true)
+			}))
+			defer srv.Close()
+			c := newTestClient(t, srv.URL)
+
+			reader := newBlockingReader()
+			defer reader.release()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := tc.call(ctx, c, reader)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected error wrapping context.Canceled, got %v", err)
+			}
+			if called.Load() {
+				t.Fatal("expected no request to reach the server")
+			}
+		})
+	}
+}
+
+func TestMultipartWriterStopsWhenServerRespondsEarly(t *testing.T) {
+	for _, tc := range multipartCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Answer without waiting for the body, which never ends.
+				_ = http.NewResponseController(w).EnableFullDuplex()
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"isSuccess":false,"error":"rejected"}`)
+			}))
+			defer srv.Close()
+			c := newTestClient(t, srv.URL)
+
+			// The reader is only released once the call returned, so the
+			// writer goroutine is still blocked in it when the response is
+			// handled.
+			reader := newBlockingReader()
+			defer reader.release()
+
+			var apiErr *client.APIError
+			err := tc.call(context.Background(), c, reader)
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+				t.Fatalf("expected a 400 *APIError, got %v", err)
+			}
+		})
+	}
+}
+
+// triggerCalls are the trigger calls whose response data is decoded.
+var triggerCalls = []struct {
+	name string
+	call func(ctx context.Context, c client.Client) error
+}{
+	{"TriggerJSON", func(ctx context.Context, c client.Client) error {
+		_, err := c.TriggerJSON(ctx, map[string]interface{}{"key": "value"})
+		return err
+	}},
+	{"TriggerForm", func(ctx context.Context, c client.Client) error {
+		_, err := c.TriggerForm(ctx, map[string]interface{}{"key": "value"}, nil, "", nil)
+		return err
+	}},
+}
+
+func TestTriggerLargeResponseWithCancelledContext(t *testing.T) {
+	const (
+		chunkSize = 1 << 20
+		chunks    = 8
+	)
+	chunk := strings.Repeat("0,", chunkSize/2)
+
+	for _, tc := range triggerCalls {
+		for _, cancelMidBody := range []bool{false, true} {
+			name := tc.name + "/already cancelled"
+			if cancelMidBody {
+				name = tc.name + "/cancelled mid body"
+			}
+			t.Run(name, func(t *testing.T) {
+				var written atomic.Int64
+				firstChunk := make(chan struct{})
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = io.WriteString(w, `{"isSuccess":true,"data":[`)
+					for i := 0; i < chunks; i++ {
+						n, err := io.WriteString(w, chunk)
+						written.Add(int64(n))
+						if err != nil {
+							return
+						}
+						w.(http.Flusher).Flush()
+						if i == 0 {
+							close(firstChunk)
+							// Hold the rest back until the client is gone,
+							// so it cannot have read the whole body.
+							select {
+							case <-r.Context().Done():
+								return
+							case <-time.After(5 * time.Second):
+							}
+						}
+					}
+					_, _ = io.WriteString(w, `0]}`)
+				}))
+				defer srv.Close()
+				c := newTestClient(t, srv.URL)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				if cancelMidBody {
+					go func() {
+						<-firstChunk
+						cancel()
+					}()
+				} else {
+					cancel()
+				}
+
+				start := time.Now()
+				err := tc.call(ctx, c)
+				if !errors.Is(err, context.Canceled) {
+					t.Fatalf("expected error wrapping context.Canceled, got %v", err)
+				}
+				if elapsed := time.Since(start); elapsed > 4*time.Second {
+					t.Fatalf("expected the call to return once cancelled, took %s", elapsed)
+				}
+				srv.Close()
+				if n := written.Load(); n >= chunkSize*chunks {
+					t.Fatalf("expected less than the whole %d byte body to be sent, sent %d", chunkSize*chunks, n)
+				}
+			})
+		}
+	}
+}
+
+// slowBody serves a JSON envelope whose data array arrives one chunk at a
+// time. It ignores the request context, so only the caller can stop reading.
+type slowBody struct {
+	chunks int
+	buf    strings.Reader
+	sent   int
+	first  chan struct{}
+	once   sync.Once
+}
+
+func (b *slowBody) Read(p []byte) (int, error) {
+	if b.buf.Len() == 0 {
+		switch {
+		case b.sent == 0:
+			b.buf.Reset(`{"isSuccess":true,"data":[`)
+		case b.sent <= b.chunks:
+			if b.sent > 1 {
+				time.Sleep(50 * time.Millisecond)
+			}
+			b.buf.Reset(strings.Repeat("0,", 1<<15))
+		case b.sent == b.chunks+1:
+			b.buf.Reset(`0]}`)
+		default:
+			return 0, io.EOF
+		}
+		if b.sent == 1 {
+			b.once.Do(func() { close(b.first) })
+		}
+		b.sent++
+	}
+	return b.buf.Read(p)
+}
+
+func (b *slowBody) Close() error { return nil }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestTriggerDecodeStopsWhenCancelledMidBody(t *testing.T) {
+	const chunks = 100
+	for _, tc := range triggerCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			body := &slowBody{chunks: chunks, first: make(chan struct{})}
+			c := client.NewBotProviderClientWithConfig(&client.BotProviderConfig{
+				EdgeServerHost:    "http://edge.invalid",
+				Namespace:         "default",
+				BotProviderName:   "test-bot",
+				BotProviderApiKey: "test-key",
+				HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					if r.Body != nil {
+						_, _ = io.Copy(io.Discard, r.Body)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": {"application/json"}},
+						Body:       body,
+						Request:    r,
+					}, nil
+				})},
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				<-body.first
+				cancel()
+			}()
+
+			start := time.Now()
+			err := tc.call(ctx, c)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected error wrapping context.Canceled, got %v", err)
+			}
+			// Reading the whole body takes about 5s.
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Fatalf("expected the decode to stop once cancelled, took %s", elapsed)
+			}
+			if body.sent > chunks/2 {
+				t.Fatalf("expected the decode to stop early, read %d of %d chunks", body.sent, chunks)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// CancelProcess asks the EdgeServer to stop one process of a run, identified
+// by the RequestId of the run's events and the ProcessId of its ProcessStart
+// or ToolCallStart event, without aborting the rest of the run. It returns an
+// error wrapping ErrNotSupported when the EdgeServer has no process
+// cancellation endpoint. A 404, e.g. for a run that already ended, is returned
+// as an *APIError.
+func (c *BotProviderClient) CancelProcess(ctx context.Context, requestID, processID string) error {
+	if requestID == "" || processID == "" {
+		return fmt.Errorf("requestID and processID cannot be empty")
+	}
+	return c.cancel(ctx, HookEndpointCancelProcess, "process/cancel", "cancel process", map[string]string{
+		"requestId": requestID,
+		"processId": processID,
+	})
+}
+
+// CancelRun asks the EdgeServer to stop the run identified by requestID on
+// customChannelID, so it stops generating and frees its resources. Closing a
+// stream only drops the connection and leaves the run going; get the
+// RequestId of a stream's run from its events or, before any arrived, from
+// RequestIDStreamer. It returns an error wrapping ErrNotSupported when the
+// EdgeServer has no run cancellation endpoint. A 404, e.g. for a run that
+// already ended, is returned as an *APIError.
+func (c *BotProviderClient) CancelRun(ctx context.Context, customChannelID, requestID string) error {
+	if customChannelID == "" || requestID == "" {
+		return fmt.Errorf("customChannelID and requestID cannot be empty")
+	}
+	return c.cancel(ctx, HookEndpointCancelRun, "message/cancel", "cancel run", map[string]string{
+		"customChannelId": outgoingChannelID(c.config, customChannelID),
+		"requestId":       requestID,
+	})
+}
+
+// cancel posts a cancellation request to path, reporting it to the hooks as
+// endpoint.
+func (c *BotProviderClient) cancel(ctx context.Context, endpoint, path, operation string, request map[string]string) (err error) {
+	ctx, hook := startRequest(ctx, c.config, endpoint)
+	defer func() { hook.done(err) }()
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", operation, err)
+	}
+
+	resp, err := c.doJSONWithFailover(ctx, path, defaultJSONContentType, body, nil)
+	if err != nil {
+		return requestError(ctx, "failed to "+operation, err)
+	}
+	defer resp.Body.Close()
+	hook.status = resp.StatusCode
+
+	switch resp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%s failed (%d): %w", operation, resp.StatusCode, ErrNotSupported)
+	}
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return requestError(ctx, "failed to read response body", err)
+	}
+
+	if _, err := decodeResponse[json.RawMessage](c.config, operation, resp, respBytes); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+)
+
+func TestCancelStatus(t *testing.T) {
+	calls := []struct {
+		name string
+		path string
+		call func(c client.Client) error
+	}{
+		{"CancelRun", "/ns/default/bot-provider/test-bot/message/cancel", func(c client.Client) error {
+			return c.CancelRun(context.Background(), "ch", "req-1")
+		}},
+		{"CancelProcess", "/ns/default/bot-provider/test-bot/process/cancel", func(c client.Client) error {
+			return c.CancelProcess(context.Background(), "req-1", "proc-1")
+		}},
+	}
+	tests := []struct {
+		status       int
+		notSupported bool
+	}{
+		{http.StatusNotFound, false},
+		{http.StatusMethodNotAllowed, true},
+		{http.StatusNotImplemented, true},
+	}
+	for _, call := range calls {
+		for _, tc := range tests {
+			t.Run(call.name+"/"+http.StatusText(tc.status), func(t *testing.T) {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != call.path {
+						t.Errorf("expected a request to %s, got %s", call.path, r.URL.Path)
+					}
+					w.WriteHeader(tc.status)
+					_, _ = w.Write([]byte(`{"isSuccess":false,"error":"no such request"}`))
+				}))
+				defer srv.Close()
+
+				err := call.call(newTestClient(t, srv.URL))
+				if got := errors.Is(err, client.ErrNotSupported); got != tc.notSupported {
+					t.Fatalf("expected ErrNotSupported=%v, got %v", tc.notSupported, err)
+				}
+				var apiErr *client.APIError
+				if !tc.notSupported && (!errors.As(err, &apiErr) || apiErr.StatusCode != tc.status) {
+					t.Fatalf("expected an *APIError with status %d, got %v", tc.status, err)
+				}
+			})
+		}
+	}
+}
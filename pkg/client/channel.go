@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// Channel binds a BotAgent to one conversation channel.
+//
+// Attaching is purely a client-side binding: no request is made and nothing
+// is created on EdgeServer. Messages sent through the handle carry the
+// channel ID, so attaching to the ID of an existing channel continues that
+// conversation exactly as sending with the same CustomChannelId would, e.g.
+// after a process restart.
+//
+// EdgeServer creates a channel implicitly with its first message and neither
+// replies nor events say whether a message created one. Confirmed is the
+// best-effort substitute: it treats the first successful reply on the
+// channel as confirmation that the channel exists server-side.
+//
+// The handle can also accumulate blob IDs and payload fields that are sent
+// with every following message until cleared. A RESET_CHANNEL message sent
+// through a handle with SetClearOnReset(true) clears them first, so the reset
+// and the messages after it start without stale attachments.
+type Channel struct {
+	agent        BotAgent
+	id           string
+	mu           sync.Mutex
+	blobIDs      []string
+	payload      map[string]interface{}
+	clearOnReset bool
+	confirmed    bool
+}
+
+// AttachChannel returns a handle bound to channelID.
+func (a *botAgent) AttachChannel(channelID string) *Channel {
+	return &Channel{agent: a, id: channelID}
+}
+
+// ID returns the channel ID the handle is bound to.
+func (c *Channel) ID() string {
+	return c.id
+}
+
+// SendMessage sends message on the channel. The message's CustomChannelId is
+// set to the channel ID.
+func (c *Channel) SendMessage(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (*models.GenericBotReply, error) {
+	if err := c.bind(message); err != nil {
+		return nil, err
+	}
+	reply, err := c.agent.SendMessage(ctx, message, isDebug)
+	// The reply echoes the channel ID as sent, which with a
+	// ChannelIDTransformer is not c.id, so any channel ID confirms.
+	if (err == nil || errors.Is(err, ErrEmptyReply)) && reply != nil && reply.CustomChannelId != "" {
+		c.mu.Lock()
+		c.confirmed = true
+		c.mu.Unlock()
+	}
+	return reply, err
+}
+
+// Confirmed reports whether a SendMessage call on the handle got a successful
+// reply carrying a channel ID, i.e. whether the channel is known to exist on
+// EdgeServer. Streams do not confirm a channel.
+func (c *Channel) Confirmed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.confirmed
+}
+
+// NewStreamer streams the reply to message on the channel. The message's
+// CustomChannelId is set to the channel ID.
+func (c *Channel) NewStreamer(ctx context.Context, message *models.GenericBotMessage) (BotProviderStreamer, error) {
+	if err := c.bind(message); err != nil {
+		return nil, err
+	}
+	return c.agent.NewStreamer(ctx, message)
+}
+
+// UploadBlob uploads a blob to the channel.
+func (c *Channel) UploadBlob(ctx context.Context, reader io.Reader, filename string, mime *string) (*models.Blob, error) {
+	return c.agent.UploadBlob(ctx, c.id, reader, filename, mime)
+}
+
+// UploadBlobFile uploads the file at path to the channel.
+func (c *Channel) UploadBlobFile(ctx context.Context, path string, mime *string) (*models.Blob, error) {
+	return c.agent.UploadBlobFile(ctx, c.id, path, mime)
+}
+
+// UploadBlobs uploads files to the channel in a single request.
+func (c *Channel) UploadBlobs(ctx context.Context, files []BlobUpload) ([]models.Blob, error) {
+	return c.agent.UploadBlobs(ctx, c.id, files)
+}
+
+// AttachBlob adds blobID to the blobs sent with every following message.
+func (c *Channel) AttachBlob(blobID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blobIDs = append(c.blobIDs, blobID)
+}
+
+// Blobs returns the attached blob IDs.
+func (c *Channel) Blobs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.blobIDs...)
+}
+
+// ClearBlobs drops every attached blob ID.
+func (c *Channel) ClearBlobs() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blobIDs = nil
+}
+
+// SetPayload sets a payload field sent with every following message. Fields
+// of a message's own payload take precedence.
+func (c *Channel) SetPayload(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.payload == nil {
+		c.payload = make(map[string]interface{})
+	}
+	c.payload[key] = value
+}
+
+// Payload returns a copy of the accumulated payload fields.
+func (c *Channel) Payload() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.payload == nil {
+		return nil
+	}
+	payload := make(map[string]interface{}, len(c.payload))
+	for k, v := range c.payload {
+		payload[k] = v
+	}
+	return payload
+}
+
+// ClearPayload drops every accumulated payload field.
+func (c *Channel) ClearPayload() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.payload = nil
+}
+
+// SetClearOnReset controls whether sending a RESET_CHANNEL message clears
+// the attached blobs and payload fields before the reset is sent. It is off
+// by default.
+func (c *Channel) SetClearOnReset(clear bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clearOnReset = clear
+}
+
+// bind sets the channel ID on message and merges in the accumulated blobs and
+// payload fields.
+func (c *Channel) bind(message *models.GenericBotMessage) error {
+	if message == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+	message.CustomChannelId = c.id
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.clearOnReset && message.Action == models.PostBackActionResetChanel {
+		c.blobIDs = nil
+		c.payload = nil
+		return nil
+	}
+
+	if len(c.blobIDs) > 0 {
+		message.BlobIds = append(append([]string(nil), c.blobIDs...), message.BlobIds...)
+	}
+	if len(c.payload) > 0 {
+		payload := make(map[string]interface{}, len(c.payload)+len(message.Payload))
+		for k, v := range c.payload {
+			payload[k] = v
+		}
+		for k, v := range message.Payload {
+			payload[k] = v
+		}
+		message.Payload = payload
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package client_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client/clienttest"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+func TestChannelClearOnReset(t *testing.T) {
+	attached := struct {
+		blobIDs []string
+		payload map[string]interface{}
+	}{
+		blobIDs: []string{"blob-1", "blob-2"},
+		payload: map[string]interface{}{"locale": "en-US"},
+	}
+
+	tests := []struct {
+		name         string
+		clearOnReset bool
+		// wantBlobIDs and wantPayload are expected on the reset and on the
+		// message after it.
+		wantBlobIDs []string
+		wantPayload map[string]interface{}
+	}{
+		{name: "clears", clearOnReset: true},
+		{name: "keeps", clearOnReset: false, wantBlobIDs: attached.blobIDs, wantPayload: attached.payload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := clienttest.NewMockClient()
+			mock.QueueReply(&models.GenericBotReply{CustomChannelId: "channel-1"}, nil)
+			mock.QueueReply(&models.GenericBotReply{CustomChannelId: "channel-1"}, nil)
+
+			ch := client.NewBotAgentFromClient(mock).AttachChannel("channel-1")
+			ch.SetClearOnReset(tt.clearOnReset)
+			for _, id := range attached.blobIDs {
+				ch.AttachBlob(id)
+			}
+			for k, v := range attached.payload {
+				ch.SetPayload(k, v)
+			}
+
+			ctx := context.Background()
+			if _, err := ch.SendMessage(ctx, &models.GenericBotMessage{Action: models.PostBackActionResetChanel}, false); err != nil {
+				t.Fatalf("SendMessage reset: %v", err)
+			}
+			if _, err := ch.SendMessage(ctx, &models.GenericBotMessage{Text: "hello", Action: models.PostBackActionNone}, false); err != nil {
+				t.Fatalf("SendMessage: %v", err)
+			}
+
+			sent := mock.Messages()
+			if len(sent) != 2 {
+				t.Fatalf("expected 2 sent messages, got %d", len(sent))
+			}
+			for i, msg := range sent {
+				if msg.CustomChannelId != "channel-1" {
+					t.Errorf("message %d: expected channel-1, got %q", i, msg.CustomChannelId)
+				}
+				if !reflect.DeepEqual(msg.BlobIds, tt.wantBlobIDs) {
+					t.Errorf("message %d: expected blob IDs %v, got %v", i, tt.wantBlobIDs, msg.BlobIds)
+				}
+				if !reflect.DeepEqual(msg.Payload, tt.wantPayload) {
+					t.Errorf("message %d: expected payload %v, got %v", i, tt.wantPayload, msg.Payload)
+				}
+			}
+
+			if got := ch.Blobs(); !reflect.DeepEqual(got, tt.wantBlobIDs) {
+				t.Errorf("expected attached blobs %v, got %v", tt.wantBlobIDs, got)
+			}
+			if got := ch.Payload(); !reflect.DeepEqual(got, tt.wantPayload) {
+				t.Errorf("expected accumulated payload %v, got %v", tt.wantPayload, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// ChatEntry is one turn of a ChatHistory: a message, or for
+// models.ChatRoleTool a tool call the bot made while answering.
+type ChatEntry struct {
+	Role     models.ChatRole        `json:"role"`
+	Message  models.BufferedMessage `json:"message"`
+	ToolCall *ChatToolCall          `json:"toolCall,omitempty"`
+}
+
+// ChatToolCall is a completed tool call recorded in a ChatHistory.
+type ChatToolCall struct {
+	ProcessId string          `json:"processId"`
+	CallSeq   int             `json:"callSeq"`
+	ToolCall  models.ToolCall `json:"toolCall"`
+	Result    interface{}     `json:"result"`
+}
+
+// ChatHistory is the running history of a conversation. It is safe for
+// concurrent use.
+type ChatHistory struct {
+	mu      sync.Mutex
+	entries []ChatEntry
+}
+
+// NewChatHistory creates an empty ChatHistory.
+func NewChatHistory() *ChatHistory {
+	return &ChatHistory{}
+}
+
+// Append adds a message to the end of the history.
+func (h *ChatHistory) Append(role models.ChatRole, message models.BufferedMessage) {
+	h.append(ChatEntry{Role: role, Message: message})
+}
+
+func (h *ChatHistory) append(entry ChatEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+}
+
+// Entries returns a copy of the history, oldest first.
+func (h *ChatHistory) Entries() []ChatEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]ChatEntry(nil), h.entries...)
+}
+
+// Len returns the number of entries in the history.
+func (h *ChatHistory) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.entries)
+}
+
+// AppendFromStream reads stream to the end and appends its turn to the
+// history: every completed message as a models.ChatRoleAssistant entry,
+// assembled as CollectStream would, and every completed tool call as a
+// models.ChatRoleTool entry, in the order they completed. When ctx ends first
+// the stream is closed and ctx's error returned. On a stream error the entries
+// completed so far are kept. The stream is not closed otherwise.
+func (h *ChatHistory) AppendFromStream(ctx context.Context, stream BotProviderStreamer) error {
+	stop := context.AfterFunc(ctx, func() { stream.Close() })
+	defer stop()
+
+	acc := NewStreamAccumulator(CollectOptions{})
+	for stream.Next() {
+		ev := stream.Current()
+		acc.Add(ev)
+
+		switch {
+		case ev.Fact.MessageComplete != nil:
+			messages := acc.reply.Messages
+			h.Append(models.ChatRoleAssistant, messages[len(messages)-1])
+		case ev.Fact.ToolCallComplete != nil:
+			f := ev.Fact.ToolCallComplete
+			h.append(ChatEntry{
+				Role: models.ChatRoleTool,
+				ToolCall: &ChatToolCall{
+					ProcessId: f.ProcessId,
+					CallSeq:   f.CallSeq,
+					ToolCall:  f.ToolCall,
+					Result:    f.ToolCallResult,
+				},
+			})
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return stream.Err()
+}
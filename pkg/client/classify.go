@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+)
+
+// IsAuthError reports whether err is the EdgeServer rejecting the API key,
+// i.e. an *APIError with status 401 or 403. These are not worth retrying
+// with the same credentials; failover moves on to the next endpoint for them.
+func IsAuthError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && isAuthStatus(apiErr.StatusCode)
+}
+
+// IsRetryable reports whether retrying the call that returned err may
+// succeed. Connection failures, request timeouts and API errors with status
+// 408, 425, 429, 500, 502, 503 or 504 are retryable. Cancellation, the
+// caller's own context deadline, auth failures, other client errors,
+// malformed responses, certificate and unknown host errors, request errors
+// such as a malformed URL, run errors and ErrNotSupported are not.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Err == nil && isRetryableStatus(apiErr.StatusCode)
+	}
+
+	// A request whose context was done also wraps the transport failure
+	// this caused, which says nothing about a retry.
+	var ctxErr *contextError
+	if errors.As(err, &ctxErr) || errors.Is(err, context.Canceled) || errors.Is(err, ErrNotSupported) {
+		return false
+	}
+
+	// Bad certificates and unknown hosts do not fix themselves.
+	var certErr *tls.CertificateVerificationError
+	var dnsErr *net.DNSError
+	if errors.As(err, &certErr) || (errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		return false
+	}
+
+	// Every http.Client failure is a *url.Error, so only the failures of the
+	// connection itself count: dial, read and write errors, request timeouts
+	// and the server dropping the connection. Bad URLs, unsupported schemes
+	// and redirect policy errors are not retryable.
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && (urlErr.Timeout() || errors.Is(urlErr.Err, io.EOF)) {
+		return true
+	}
+	// A bare DeadlineExceeded, which is a net.Error too, is the caller's
+	// context running out.
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+func isAuthStatus(code int) bool {
+	return code == http.StatusUnauthorized || code == http.StatusForbidden
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout,
+		http.StatusTooEarly,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
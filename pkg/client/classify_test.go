@@ -0,0 +1,89 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Post", URL: "http://edge/message", Err: err}
+	}
+
+	_, parseErr := url.Parse("http://[::1")
+	_, unsupportedScheme := (&http.Client{}).Get("ftp://edge/message")
+	redirectErr := urlErr(fmt.Errorf("stopped after 10 redirects"))
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"connection refused", urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), true},
+		{"connection reset", urlErr(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}), true},
+		{"timeout", urlErr(timeoutError{}), true},
+		{"server closed connection", urlErr(io.EOF), true},
+		{"unexpected EOF", fmt.Errorf("read body: %w", io.ErrUnexpectedEOF), true},
+		{"retryable status", &client.APIError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"auth status", &client.APIError{StatusCode: http.StatusUnauthorized}, false},
+		{"canceled", fmt.Errorf("send: %w", context.Canceled), false},
+		{"context deadline", fmt.Errorf("send: %w", context.DeadlineExceeded), false},
+		{"malformed URL", urlErr(parseErr), false},
+		{"unsupported protocol scheme", unsupportedScheme, false},
+		{"redirect policy", redirectErr, false},
+		{"unknown host", urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "edge", IsNotFound: true}}), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := client.IsRetryable(tc.err); got != tc.want {
+				t.Fatalf("IsRetryable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+
+	var urlError *url.Error
+	if !errors.As(unsupportedScheme, &urlError) {
+		t.Fatalf("expected http.Client to fail with a *url.Error, got %T", unsupportedScheme)
+	}
+}
+
+func TestRequestErrorKeepsTransportFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.TriggerJSON(ctx, map[string]interface{}{"key": "value"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected error wrapping context.DeadlineExceeded, got %v", err)
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatalf("expected the transport failure to be kept, got %v", err)
+	}
+	if client.IsRetryable(err) {
+		t.Fatalf("expected the caller's deadline not to be retryable: %v", err)
+	}
+}
@@ -0,0 +1,456 @@
+// Package clienttest provides a test double of client.Client, so code built
+// on the SDK can be tested without an EdgeServer.
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// ErrNotQueued is wrapped by the error a MockClient call returns when no
+// response was queued for it.
+var ErrNotQueued = errors.New("clienttest: no response queued")
+
+// Upload is a blob upload recorded by a MockClient.
+type Upload struct {
+	ChannelID string
+	Filename  string
+	Mime      string
+	Data      []byte
+}
+
+// Cancel is a CancelProcess or CancelRun call recorded by a MockClient.
+// ProcessID is empty for CancelRun and ChannelID for CancelProcess.
+type Cancel struct {
+	ChannelID string
+	RequestID string
+	ProcessID string
+}
+
+type replyResult struct {
+	reply *models.GenericBotReply
+	err   error
+}
+
+type streamResult struct {
+	events []models.GenericBotSseEvent
+	err    error
+}
+
+type triggerResult struct {
+	value interface{}
+	err   error
+}
+
+type blobResult struct {
+	blob *models.Blob
+	err  error
+}
+
+// MockClient is a client.Client that answers from queued responses and
+// records what it was sent. Each call takes the next response queued for it,
+// oldest first, and fails with ErrNotQueued when there is none. Wrap it with
+// client.NewBotAgentFromClient or client.NewFunctionAgentFromClient to test
+// code that takes an agent. It is safe for concurrent use.
+type MockClient struct {
+	// InvalidAPIKey makes VerifyAPIKey report the key as rejected.
+	InvalidAPIKey bool
+	// CancelErr is returned by CancelProcess and CancelRun.
+	CancelErr error
+	// PingErr is returned by Ping. InvalidAPIKey makes Ping fail with
+	// client.ErrUnauthorized instead.
+	PingErr error
+
+	mu       sync.Mutex
+	replies  []replyResult
+	streams  []streamResult
+	triggers []triggerResult
+	blobs    []blobResult
+
+	messages []models.GenericBotMessage
+	payloads []map[string]interface{}
+	uploads  []Upload
+	cancels  []Cancel
+	uploaded map[string][]models.Blob
+	history  map[string][]models.BufferedMessage
+	media    map[string]media
+}
+
+type media struct {
+	contentType string
+	data        []byte
+}
+
+var _ client.Client = (*MockClient)(nil)
+
+// NewMockClient creates a MockClient with nothing queued.
+func NewMockClient() *MockClient {
+	return &MockClient{
+		uploaded: make(map[string][]models.Blob),
+		history:  make(map[string][]models.BufferedMessage),
+		media:    make(map[string]media),
+	}
+}
+
+// QueueReply queues the reply, or error, of a SendMessage or ReadMessages
+// call.
+func (m *MockClient) QueueReply(reply *models.GenericBotReply, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.replies = append(m.replies, replyResult{reply: reply, err: err})
+}
+
+// QueueStream queues the events of a NewStreamer, NewConversationStreamer,
+// ConversationStreamer.Send or TriggerJSONStream call. The stream yields the
+// events and then ends with err, which may be nil.
+func (m *MockClient) QueueStream(events []models.GenericBotSseEvent, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.streams = append(m.streams, streamResult{events: events, err: err})
+}
+
+// QueueTrigger queues the result, or error, of a TriggerJSON or TriggerForm
+// call.
+func (m *MockClient) QueueTrigger(value interface{}, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.triggers = append(m.triggers, triggerResult{value: value, err: err})
+}
+
+// QueueBlob queues the blob, or error, of an UploadBlob or
+// UploadBlobWithStats call. Uploaded blobs are listed by ListBlobs.
+func (m *MockClient) QueueBlob(blob *models.Blob, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.blobs = append(m.blobs, blobResult{blob: blob, err: err})
+}
+
+// SetHistory sets the history of customChannelID served by
+// GetChannelHistory. It is sorted oldest first, like the pages of the real
+// client.
+func (m *MockClient) SetHistory(customChannelID string, messages []models.BufferedMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	history := append([]models.BufferedMessage(nil), messages...)
+	models.SortMessagesByIdx(history)
+	m.history[customChannelID] = history
+}
+
+// SetMedia sets the content served for url by DownloadReplyAttachments.
+func (m *MockClient) SetMedia(url, contentType string, data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.media[url] = media{contentType: contentType, data: append([]byte(nil), data...)}
+}
+
+// Messages returns copies of the messages sent through SendMessage,
+// ReadMessages and the streamers, in the order they were sent.
+func (m *MockClient) Messages() []models.GenericBotMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]models.GenericBotMessage(nil), m.messages...)
+}
+
+// Payloads returns the payloads of the TriggerJSON, TriggerJSONStream and
+// TriggerForm calls, in call order.
+func (m *MockClient) Payloads() []map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]map[string]interface{}(nil), m.payloads...)
+}
+
+// Uploads returns the files of the UploadBlob, UploadBlobWithStats and
+// TriggerForm calls, in call order.
+func (m *MockClient) Uploads() []Upload {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Upload(nil), m.uploads...)
+}
+
+// Cancels returns the CancelProcess and CancelRun calls, in call order.
+func (m *MockClient) Cancels() []Cancel {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Cancel(nil), m.cancels...)
+}
+
+func (m *MockClient) recordMessage(message *models.GenericBotMessage) error {
+	if message == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+	m.messages = append(m.messages, *message)
+	return nil
+}
+
+func (m *MockClient) nextReply(message *models.GenericBotMessage) (*models.GenericBotReply, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.recordMessage(message); err != nil {
+		return nil, err
+	}
+	if len(m.replies) == 0 {
+		return nil, fmt.Errorf("reply: %w", ErrNotQueued)
+	}
+	r := m.replies[0]
+	m.replies = m.replies[1:]
+	return r.reply, r.err
+}
+
+func (m *MockClient) nextStream(message *models.GenericBotMessage) (client.BotProviderStreamer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.recordMessage(message); err != nil {
+		return nil, err
+	}
+	return m.popStream()
+}
+
+func (m *MockClient) popStream() (client.BotProviderStreamer, error) {
+	if len(m.streams) == 0 {
+		return nil, fmt.Errorf("stream: %w", ErrNotQueued)
+	}
+	r := m.streams[0]
+	m.streams = m.streams[1:]
+	return StreamFromEventsWithError(r.events, r.err), nil
+}
+
+func (m *MockClient) nextTrigger(payload map[string]interface{}) (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.payloads = append(m.payloads, payload)
+	if len(m.triggers) == 0 {
+		return nil, fmt.Errorf("trigger: %w", ErrNotQueued)
+	}
+	r := m.triggers[0]
+	m.triggers = m.triggers[1:]
+	return r.value, r.err
+}
+
+func (m *MockClient) NewStreamer(ctx context.Context, message *models.GenericBotMessage) (client.BotProviderStreamer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.nextStream(message)
+}
+
+func (m *MockClient) NewConversationStreamer(ctx context.Context, message *models.GenericBotMessage) (client.ConversationStreamer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stream, err := m.nextStream(message)
+	if err != nil {
+		return nil, err
+	}
+	return &conversation{BotProviderStreamer: stream, mock: m}, nil
+}
+
+func (m *MockClient) SendMessage(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (*models.GenericBotReply, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.nextReply(message)
+}
+
+func (m *MockClient) ReadMessages(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (client.MessageReader, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	reply, err := m.nextReply(message)
+	if err != nil {
+		return nil, err
+	}
+	var messages []models.BufferedMessage
+	if reply != nil {
+		messages = reply.Messages
+	}
+	return &messageReader{messages: messages}, nil
+}
+
+// GetChannelHistory pages through the history set with SetHistory like the
+// real client: up to limit messages older than before, oldest first.
+func (m *MockClient) GetChannelHistory(ctx context.Context, customChannelID string, limit int, before *string) ([]models.BufferedMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	history := m.history[customChannelID]
+	end := len(history)
+	if before != nil && *before != "" {
+		for i, msg := range history {
+			if msg.MessageId == *before {
+				end = i
+				break
+			}
+		}
+	}
+	start := 0
+	if limit > 0 && end-limit > 0 {
+		start = end - limit
+	}
+	return append([]models.BufferedMessage{}, history[start:end]...), nil
+}
+
+func (m *MockClient) TriggerJSON(ctx context.Context, payload map[string]interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.nextTrigger(payload)
+}
+
+func (m *MockClient) TriggerJSONStream(ctx context.Context, payload map[string]interface{}) (client.BotProviderStreamer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.payloads = append(m.payloads, payload)
+	return m.popStream()
+}
+
+func (m *MockClient) TriggerForm(ctx context.Context, payload map[string]interface{}, reader io.Reader, filename string, mime *string) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := m.recordUpload("", reader, filename, mime); err != nil {
+		return nil, err
+	}
+	return m.nextTrigger(payload)
+}
+
+func (m *MockClient) recordUpload(channelID string, reader io.Reader, filename string, mime *string) error {
+	upload := Upload{ChannelID: channelID, Filename: filename}
+	if mime != nil {
+		upload.Mime = *mime
+	}
+	if reader != nil {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("failed to read upload: %w", err)
+		}
+		upload.Data = data
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.uploads = append(m.uploads, upload)
+	return nil
+}
+
+func (m *MockClient) UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := m.recordUpload(customChannelID, reader, filename, mime); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.blobs) == 0 {
+		return nil, fmt.Errorf("UploadBlob: %w", ErrNotQueued)
+	}
+	r := m.blobs[0]
+	m.blobs = m.blobs[1:]
+	if r.err == nil && r.blob != nil {
+		m.uploaded[customChannelID] = append(m.uploaded[customChannelID], *r.blob)
+	}
+	return r.blob, r.err
+}
+
+func (m *MockClient) UploadBlobWithStats(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, client.UploadStats, error) {
+	blob, err := m.UploadBlob(ctx, customChannelID, reader, filename, mime)
+	return blob, client.UploadStats{}, err
+}
+
+// UploadBlobs uploads each file like UploadBlob, taking one queued blob per
+// file.
+func (m *MockClient) UploadBlobs(ctx context.Context, customChannelID string, files []client.BlobUpload) ([]models.Blob, error) {
+	blobs := make([]models.Blob, 0, len(files))
+	for _, f := range files {
+		blob, err := m.UploadBlob(ctx, customChannelID, f.Reader, f.Filename, f.Mime)
+		if err != nil {
+			return nil, err
+		}
+		if blob != nil {
+			blobs = append(blobs, *blob)
+		}
+	}
+	return blobs, nil
+}
+
+// ListBlobs returns the blobs uploaded to customChannelID through the mock.
+func (m *MockClient) ListBlobs(ctx context.Context, customChannelID string) ([]models.Blob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]models.Blob{}, m.uploaded[customChannelID]...), nil
+}
+
+// DownloadReplyAttachments serves the attachments of reply from the media
+// set with SetMedia. Attachments without media fail with ErrNotQueued.
+func (m *MockClient) DownloadReplyAttachments(ctx context.Context, reply *models.GenericBotReply) ([]client.DownloadedBlob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	blobs := client.ReplyAttachments(reply)
+	for i := range blobs {
+		media, ok := m.media[blobs[i].URL]
+		if !ok {
+			blobs[i].Err = fmt.Errorf("media %s: %w", blobs[i].URL, ErrNotQueued)
+			continue
+		}
+		blobs[i].ContentType = media.contentType
+		blobs[i].Data = append([]byte(nil), media.data...)
+	}
+	return blobs, nil
+}
+
+func (m *MockClient) CancelProcess(ctx context.Context, requestID, processID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cancels = append(m.cancels, Cancel{RequestID: requestID, ProcessID: processID})
+	return m.CancelErr
+}
+
+func (m *MockClient) CancelRun(ctx context.Context, customChannelID, requestID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cancels = append(m.cancels, Cancel{ChannelID: customChannelID, RequestID: requestID})
+	return m.CancelErr
+}
+
+func (m *MockClient) VerifyAPIKey(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return !m.InvalidAPIKey, nil
+}
+
+func (m *MockClient) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if m.InvalidAPIKey {
+		return client.ErrUnauthorized
+	}
+	return m.PingErr
+}
@@ -0,0 +1,138 @@
+package clienttest
+
+import (
+	"fmt"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// StreamFromEvents returns a BotProviderStreamer that yields events in order.
+// Like a real stream, a run error event ends it with an error wrapping the
+// event's *models.ErrorDetail.
+func StreamFromEvents(events []models.GenericBotSseEvent) client.BotProviderStreamer {
+	return StreamFromEventsWithError(events, nil)
+}
+
+// StreamFromEventsWithError is StreamFromEvents for a stream that fails with
+// err, e.g. a connection error, once its events are exhausted.
+func StreamFromEventsWithError(events []models.GenericBotSseEvent, err error) client.BotProviderStreamer {
+	return &eventStream{events: append([]models.GenericBotSseEvent(nil), events...), finalErr: err}
+}
+
+type eventStream struct {
+	mu       sync.Mutex
+	events   []models.GenericBotSseEvent
+	pos      int
+	current  *models.GenericBotSseEvent
+	finalErr error
+	err      error
+	closed   bool
+}
+
+func (s *eventStream) Next() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || s.err != nil {
+		return false
+	}
+	if s.pos >= len(s.events) {
+		s.err = s.finalErr
+		return false
+	}
+
+	ev := &s.events[s.pos]
+	s.pos++
+	if ev.EventType == models.SseEventTypeRunError {
+		detail := &models.ErrorDetail{Message: "run error event without error detail"}
+		if ev.Fact.RunError != nil {
+			detail = &ev.Fact.RunError.Error
+		}
+		s.err = fmt.Errorf("SSE stream error: %w", detail)
+		return false
+	}
+	s.current = ev
+	return true
+}
+
+// RequestID returns the RequestId of the first event yielded, like the real
+// streams' RequestIDStreamer implementation.
+func (s *eventStream) RequestID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ev := range s.events[:s.pos] {
+		if ev.RequestId != "" {
+			return ev.RequestId
+		}
+	}
+	return ""
+}
+
+func (s *eventStream) Current() *models.GenericBotSseEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+func (s *eventStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *eventStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.current = nil
+	return nil
+}
+
+// conversation plays one queued stream per turn. It is meant to be used
+// from one goroutine.
+type conversation struct {
+	client.BotProviderStreamer
+	mock *MockClient
+}
+
+// Send records message and starts the next queued stream.
+func (c *conversation) Send(message *models.GenericBotMessage) error {
+	stream, err := c.mock.nextStream(message)
+	if err != nil {
+		return err
+	}
+	_ = c.BotProviderStreamer.Close()
+	c.BotProviderStreamer = stream
+	return nil
+}
+
+// messageReader iterates over the messages of a queued reply.
+type messageReader struct {
+	messages []models.BufferedMessage
+	pos      int
+	current  *models.BufferedMessage
+}
+
+func (r *messageReader) Next() bool {
+	if r.pos >= len(r.messages) {
+		r.current = nil
+		return false
+	}
+	r.current = &r.messages[r.pos]
+	r.pos++
+	return true
+}
+
+func (r *messageReader) Current() *models.BufferedMessage {
+	return r.current
+}
+
+func (r *messageReader) Err() error {
+	return nil
+}
+
+func (r *messageReader) Close() error {
+	return nil
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"errors"
+	"strings"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// CompleteTextMode says what the text of a MessageComplete event holds.
+type CompleteTextMode int
+
+const (
+	// CompleteTextFull means MessageComplete carries the message's full text,
+	// which replaces whatever the deltas accumulated. This is the default.
+	CompleteTextFull CompleteTextMode = iota
+	// CompleteTextLastChunk means MessageComplete carries only the final chunk
+	// of text, which is appended to the accumulated deltas.
+	CompleteTextLastChunk
+)
+
+// CollectOptions configures how streamed messages are assembled.
+type CollectOptions struct {
+	CompleteText CompleteTextMode
+
+	// MaxMessageTextBytes, when positive, caps the text of assembled
+	// messages, completed or pending. Longer text is cut and ends with
+	// TruncatedTextMarker.
+	MaxMessageTextBytes int
+	// OnMessageTextTruncated, when set, is called with the message ID and
+	// original size in bytes when a completed message's text is cut.
+	OnMessageTextTruncated func(messageID string, size int)
+}
+
+// StreamAccumulator assembles streamed message events into messages. The
+// contract is: MessageStart opens a message with its text, if any;
+// MessageDelta appends its text to the open message with the same MessageId;
+// MessageComplete finalizes it, its text either replacing or extending the
+// accumulated text according to CompleteText, and its other fields replacing
+// those of the open message. A MessageComplete without a preceding
+// start or delta is taken as it is.
+type StreamAccumulator struct {
+	opts  CollectOptions
+	reply models.GenericBotReply
+	open  map[string]*openMessage
+	order []string
+}
+
+// openMessage is a message that has not completed yet.
+type openMessage struct {
+	msg  models.BufferedMessage
+	text strings.Builder
+}
+
+// NewStreamAccumulator creates an empty StreamAccumulator.
+func NewStreamAccumulator(opts CollectOptions) *StreamAccumulator {
+	return &StreamAccumulator{opts: opts, open: make(map[string]*openMessage)}
+}
+
+// Add folds one stream event into the accumulator. A run error event sets the
+// reply's ErrorDetail; other events only contribute the reply's routing
+// fields.
+func (a *StreamAccumulator) Add(ev *models.GenericBotSseEvent) {
+	if a.reply.RequestId == "" {
+		a.reply.RequestId = ev.RequestId
+		a.reply.Namespace = ev.Namespace
+		a.reply.BotProviderName = ev.BotProviderName
+		a.reply.CustomChannelId = ev.CustomChannelId
+	}
+
+	switch {
+	case ev.Fact.MessageStart != nil:
+		a.appendText(ev.Fact.MessageStart.Message)
+	case ev.Fact.MessageDelta != nil:
+		a.appendText(ev.Fact.MessageDelta.Message)
+	case ev.Fact.MessageComplete != nil:
+		a.complete(ev.Fact.MessageComplete.Message)
+	case ev.EventType == models.SseEventTypeRunError:
+		a.reply.ErrorDetail = runErrorDetail(ev)
+	}
+}
+
+func (a *StreamAccumulator) appendText(msg models.BufferedMessage) {
+	open, ok := a.open[msg.MessageId]
+	if !ok {
+		open = &openMessage{msg: msg}
+		a.open[msg.MessageId] = open
+		a.order = append(a.order, msg.MessageId)
+	}
+	// Past the limit the text is cut anyway; stop growing it.
+	if max := a.opts.MaxMessageTextBytes; max > 0 && open.text.Len() > max {
+		return
+	}
+	open.text.WriteString(msg.Text)
+}
+
+func (a *StreamAccumulator) complete(msg models.BufferedMessage) {
+	if open, ok := a.open[msg.MessageId]; ok {
+		if a.opts.CompleteText == CompleteTextLastChunk {
+			msg.Text = open.text.String() + msg.Text
+		}
+		a.closeOpen(msg.MessageId)
+	}
+	guardMessageText(&msg, a.opts.MaxMessageTextBytes, a.opts.OnMessageTextTruncated)
+	a.reply.Messages = append(a.reply.Messages, msg)
+}
+
+func (a *StreamAccumulator) closeOpen(id string) {
+	delete(a.open, id)
+	for i, openID := range a.order {
+		if openID == id {
+			a.order = append(a.order[:i], a.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// Reply returns the completed messages, in the order they completed, as
+// SendMessage would have returned them.
+func (a *StreamAccumulator) Reply() *models.GenericBotReply {
+	reply := a.reply
+	reply.Messages = append([]models.BufferedMessage(nil), a.reply.Messages...)
+	return &reply
+}
+
+// Pending returns the messages that were started or received deltas but have
+// not completed yet, with the text accumulated so far.
+func (a *StreamAccumulator) Pending() []models.BufferedMessage {
+	pending := make([]models.BufferedMessage, 0, len(a.order))
+	for _, id := range a.order {
+		msg := a.open[id].msg
+		msg.Text, _ = truncateText(a.open[id].text.String(), a.opts.MaxMessageTextBytes)
+		pending = append(pending, msg)
+	}
+	return pending
+}
+
+// CollectStream reads stream to the end and assembles its messages into a
+// GenericBotReply with the default CollectOptions. See
+// CollectStreamWithOptions.
+func CollectStream(stream BotProviderStreamer) (*models.GenericBotReply, error) {
+	return CollectStreamWithOptions(stream, CollectOptions{})
+}
+
+// CollectStreamWithOptions reads stream to the end and assembles its
+// completed messages into a GenericBotReply using a StreamAccumulator, as
+// SendMessage would have returned them. Use OrderedItems on the result to
+// render text and templates interleaved by Idx. On a stream error the
+// messages completed so far are returned along with the error; when the run
+// failed, the reply's ErrorDetail is the run's error detail, as SendMessage
+// reports it. The stream is not closed.
+func CollectStreamWithOptions(stream BotProviderStreamer, opts CollectOptions) (*models.GenericBotReply, error) {
+	acc := NewStreamAccumulator(opts)
+	for stream.Next() {
+		acc.Add(stream.Current())
+	}
+
+	// Streams end on a run error without returning its event; the detail
+	// comes with the error.
+	err := stream.Err()
+	var detail *models.ErrorDetail
+	if errors.As(err, &detail) {
+		acc.reply.ErrorDetail = detail
+	}
+	return acc.Reply(), err
+}
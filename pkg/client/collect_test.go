@@ -0,0 +1,188 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/testutil"
+)
+
+func TestStreamAccumulatorCompleteText(t *testing.T) {
+	message := func(id, text string) models.BufferedMessage {
+		return models.BufferedMessage{MessageId: id, Text: text}
+	}
+
+	tests := []struct {
+		name   string
+		mode   client.CompleteTextMode
+		events func(b *testutil.EventBuilder) []*models.GenericBotSseEvent
+		want   []string
+	}{
+		{
+			name: "full text replaces deltas",
+			mode: client.CompleteTextFull,
+			events: func(b *testutil.EventBuilder) []*models.GenericBotSseEvent {
+				return []*models.GenericBotSseEvent{
+					b.MessageStart(message("m1", "")),
+					b.MessageDelta("m1", "Hello, "),
+					b.MessageDelta("m1", "wor"),
+					b.MessageComplete(message("m1", "Hello, world")),
+				}
+			},
+			want: []string{"Hello, world"},
+		},
+		{
+			name: "last chunk extends deltas",
+			mode: client.CompleteTextLastChunk,
+			events: func(b *testutil.EventBuilder) []*models.GenericBotSseEvent {
+				return []*models.GenericBotSseEvent{
+					b.MessageStart(message("m1", "")),
+					b.MessageDelta("m1", "Hello, "),
+					b.MessageDelta("m1", "wor"),
+					b.MessageComplete(message("m1", "ld")),
+				}
+			},
+			want: []string{"Hello, world"},
+		},
+		{
+			name: "last chunk extends start text",
+			mode: client.CompleteTextLastChunk,
+			events: func(b *testutil.EventBuilder) []*models.GenericBotSseEvent {
+				return []*models.GenericBotSseEvent{
+					b.MessageStart(message("m1", "Hello")),
+					b.MessageComplete(message("m1", ", world")),
+				}
+			},
+			want: []string{"Hello, world"},
+		},
+		{
+			name: "full text without deltas",
+			mode: client.CompleteTextFull,
+			events: func(b *testutil.EventBuilder) []*models.GenericBotSseEvent {
+				return []*models.GenericBotSseEvent{
+					b.MessageComplete(message("m1", "Hello, world")),
+				}
+			},
+			want: []string{"Hello, world"},
+		},
+		{
+			name: "last chunk without deltas",
+			mode: client.CompleteTextLastChunk,
+			events: func(b *testutil.EventBuilder) []*models.GenericBotSseEvent {
+				return []*models.GenericBotSseEvent{
+					b.MessageComplete(message("m1", "Hello, world")),
+				}
+			},
+			want: []string{"Hello, world"},
+		},
+		{
+			name: "interleaved messages complete separately",
+			mode: client.CompleteTextLastChunk,
+			events: func(b *testutil.EventBuilder) []*models.GenericBotSseEvent {
+				return []*models.GenericBotSseEvent{
+					b.MessageDelta("m1", "first "),
+					b.MessageDelta("m2", "second "),
+					b.MessageComplete(message("m2", "done")),
+					b.MessageComplete(message("m1", "done")),
+				}
+			},
+			want: []string{"second done", "first done"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := client.NewStreamAccumulator(client.CollectOptions{CompleteText: tt.mode})
+			for _, ev := range tt.events(testutil.NewEventBuilder()) {
+				acc.Add(ev)
+			}
+
+			reply := acc.Reply()
+			if len(reply.Messages) != len(tt.want) {
+				t.Fatalf("expected %d messages, got %d", len(tt.want), len(reply.Messages))
+			}
+			for i, want := range tt.want {
+				if got := reply.Messages[i].Text; got != want {
+					t.Errorf("message %d: expected text %q, got %q", i, want, got)
+				}
+			}
+			if pending := acc.Pending(); len(pending) != 0 {
+				t.Errorf("expected no pending messages, got %d", len(pending))
+			}
+		})
+	}
+}
+
+// fullErrorDetail returns a run error detail with every field set.
+func fullErrorDetail() models.ErrorDetail {
+	return models.ErrorDetail{
+		Message: "processor failed",
+		Code:    "PROCESSOR_ERROR",
+		Inner:   "upstream returned 502",
+		Location: models.ErrorLocation{
+			Namespace:           "default",
+			WorkflowName:        "support-flow",
+			ProcessorName:       "llm-reply",
+			ProcessorType:       "LLM",
+			ProcessorConfigName: "gpt-config",
+			ProcessId:           "process-1",
+		},
+	}
+}
+
+func TestCollectStreamRunErrorDetail(t *testing.T) {
+	events := testutil.NewEventBuilder()
+	detail := fullErrorDetail()
+	srv := newSSEServer(t,
+		sseStep{event: events.RunInit()},
+		sseStep{event: events.MessageComplete(models.BufferedMessage{MessageId: "m1", Text: "partial"})},
+		sseStep{event: events.RunError(detail)},
+	)
+
+	stream, err := client.NewStreaming(context.Background(), newStreamConfig(srv.URL, &http.Client{}), testMessage())
+	if err != nil {
+		t.Fatalf("NewStreaming: %v", err)
+	}
+	defer stream.Close()
+
+	reply, err := client.CollectStream(stream)
+	if err == nil {
+		t.Fatal("expected the run error to be returned")
+	}
+	if !reflect.DeepEqual(reply.ErrorDetail, &detail) {
+		t.Fatalf("expected error detail %+v, got %+v", detail, reply.ErrorDetail)
+	}
+	if len(reply.Messages) != 1 || reply.Messages[0].Text != "partial" {
+		t.Fatalf("expected the message completed before the error, got %+v", reply.Messages)
+	}
+}
+
+func TestStreamErrReturnsRunErrorDetail(t *testing.T) {
+	events := testutil.NewEventBuilder()
+	want := fullErrorDetail()
+	srv := newSSEServer(t,
+		sseStep{event: events.RunInit()},
+		sseStep{event: events.RunError(want)},
+	)
+
+	stream, err := client.NewStreaming(context.Background(), newStreamConfig(srv.URL, &http.Client{}), testMessage())
+	if err != nil {
+		t.Fatalf("NewStreaming: %v", err)
+	}
+	defer stream.Close()
+
+	for stream.Next() {
+	}
+	var detail *models.ErrorDetail
+	if !errors.As(stream.Err(), &detail) {
+		t.Fatalf("expected error wrapping *models.ErrorDetail, got %v", stream.Err())
+	}
+	if detail.Location != want.Location {
+		t.Fatalf("expected location %+v, got %+v", want.Location, detail.Location)
+	}
+}
@@ -6,23 +6,45 @@ import (
 	"net/http"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
 )
 
-const defaultHTTPTimeout = 300 * time.Second
+const (
+	defaultHTTPTimeout          = 300 * time.Second
+	defaultCompressionThreshold = 1024
+	defaultSSEPath              = "message/sse"
+	defaultStreamBufferSize     = 100
+	defaultStreamReadBuffer     = 1024 * 1024
+	defaultStreamMaxTokenSize   = 10 * 1024 * 1024
+	defaultMaxErrorBodyBytes    = 4096
+)
 
 // Client defines the interface for interacting with Edge Server BotProvider APIs.
 type Client interface {
 	NewStreamer(ctx context.Context, message *models.GenericBotMessage) (BotProviderStreamer, error)
+	NewConversationStreamer(ctx context.Context, message *models.GenericBotMessage) (ConversationStreamer, error)
 	SendMessage(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (*models.GenericBotReply, error)
+	ReadMessages(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (MessageReader, error)
+	GetChannelHistory(ctx context.Context, customChannelID string, limit int, before *string) ([]models.BufferedMessage, error)
 	TriggerJSON(ctx context.Context, payload map[string]interface{}) (interface{}, error)
+	TriggerJSONStream(ctx context.Context, payload map[string]interface{}) (BotProviderStreamer, error)
 	TriggerForm(ctx context.Context, payload map[string]interface{}, reader io.Reader, filename string, mime *string) (interface{}, error)
 	UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error)
+	UploadBlobWithStats(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, UploadStats, error)
+	UploadBlobs(ctx context.Context, customChannelID string, files []BlobUpload) ([]models.Blob, error)
+	ListBlobs(ctx context.Context, customChannelID string) ([]models.Blob, error)
+	CancelProcess(ctx context.Context, requestID, processID string) error
+	CancelRun(ctx context.Context, customChannelID, requestID string) error
+	VerifyAPIKey(ctx context.Context) (bool, error)
+	Ping(ctx context.Context) error
+	DownloadReplyAttachments(ctx context.Context, reply *models.GenericBotReply) ([]DownloadedBlob, error)
 }
 
 // BotProviderClient is a typed client for Edge Server BotProvider endpoints.
 type BotProviderClient struct {
-	config *BotProviderConfig
+	config  *BotProviderConfig
+	limiter *priorityLimiter
 }
 
 // BotProviderConfig holds the configuration for connecting to the bot provider
@@ -32,13 +54,274 @@ type BotProviderConfig struct {
 	Namespace         string
 	BotProviderName   string
 	BotProviderApiKey string
-	Headers           map[string]string
+	// Headers are sent with every request, e.g. gateway headers such as
+	// X-Tenant-ID. They never replace X-API-KEY; per-call headers can be
+	// added with WithRequestOptions.
+	Headers map[string]string
+
+	// AcceptLanguage and Timezone are the default Accept-Language and
+	// X-Timezone headers of every request; RequestOptions override them per
+	// call.
+	AcceptLanguage string
+	Timezone       string
+
+	// Logger is used for the SDK's log output unless the request context
+	// carries one (see WithLogger). Defaults to the logrus standard logger.
+	Logger log.FieldLogger
+
+	// RequestCompression gzips JSON request bodies (SendMessage, TriggerJSON)
+	// larger than RequestCompressionThreshold bytes and sets
+	// Content-Encoding: gzip. Only enable it for EdgeServer deployments that
+	// accept compressed request bodies. Multipart bodies are streamed and are
+	// only compressed with FormRequestCompression.
+	RequestCompression bool
+	// RequestCompressionThreshold is the minimum body size in bytes before
+	// compression kicks in. Defaults to 1KB.
+	RequestCompressionThreshold int
+	// FormRequestCompression gzips the whole multipart body of TriggerForm,
+	// json field and file alike, as it is streamed, and sets
+	// Content-Encoding: gzip. The size of a streamed body is not known up
+	// front, so RequestCompressionThreshold does not apply.
+	FormRequestCompression bool
+
+	// ChannelIDTransformer, when set, maps every CustomChannelId before it is
+	// sent, in message and stream bodies and blob uploads, e.g. to hash user
+	// identifiers. Callers keep using the raw IDs; messages are not modified.
+	// It must be deterministic so a channel always maps to the same ID.
+	// Replies and events carry the transformed ID as the server knows it.
+	ChannelIDTransformer func(channelID string) string
+
+	// AutoMessageID fills an empty CustomMessageId with a generated unique ID
+	// in SendMessage and NewStreamer. The generated ID is written back to the
+	// message.
+	AutoMessageID bool
+
+	// MessageInterceptor, when set, is called with every outgoing message in
+	// SendMessage, NewStreamer and ReadMessages just before it is sent, after
+	// AutoMessageID, to inject common fields such as tenant tags. It may
+	// modify the message in place, which the caller sees; a non-nil error
+	// rejects the message and is returned without sending anything.
+	MessageInterceptor func(message *models.GenericBotMessage) error
+
+	// ErrorOnEmptyReply makes SendMessage return ErrEmptyReply, along with the
+	// reply, when the server reports success without any messages, so callers
+	// can tell it apart from a reply and decide whether to wait or retry. By
+	// default the empty reply is returned without an error.
+	ErrorOnEmptyReply bool
+
+	// Outbox, when set, stores messages whose SendMessage call failed with a
+	// retryable error, such as a network failure or a 5xx, so an
+	// OutboxWorker can resend them. SendMessage still returns the error,
+	// wrapping ErrQueuedInOutbox once the message is stored.
+	Outbox OutboxStore
+
+	// SendRateLimit, when positive, caps SendMessage calls to this many per
+	// second, with bursts of up to SendBurst (default 1). Calls over the limit
+	// wait their turn in RequestOptions.Priority order.
+	SendRateLimit float64
+	SendBurst     int
+
+	// MaxErrorBodyBytes caps how much of a failed response's body is kept in
+	// APIError.Body. Defaults to 4KB; negative omits the body.
+	MaxErrorBodyBytes int
+
+	// MaxMessageTextBytes, when positive, caps the text of messages returned
+	// by SendMessage and ReadMessages. Longer text is cut and ends with
+	// TruncatedTextMarker, unless the limit is too small to hold it.
+	// CollectOptions has the same guard for streams.
+	MaxMessageTextBytes int
+	// OnMessageTextTruncated, when set, is called with the message ID and
+	// original size in bytes of every text cut by MaxMessageTextBytes.
+	OnMessageTextTruncated func(messageID string, size int)
+
+	// MinTLSVersion is the minimum TLS version (a tls.VersionTLS* constant)
+	// accepted by the HTTP clients the SDK builds itself. It has no effect on
+	// a caller-supplied HTTPClient or SSEHTTPClient. Defaults to TLS 1.2.
+	MinTLSVersion uint16
+
+	// HostHeader, when set, is sent as the Host header of every request
+	// instead of the host of the URL, e.g. to reach EdgeServer by IP behind
+	// virtual-host routing.
+	HostHeader string
+	// ServerName, when set, is the TLS SNI name and the name certificates are
+	// verified against, instead of the host of the URL. Like MinTLSVersion it
+	// only applies to the HTTP clients the SDK builds itself.
+	ServerName string
+
+	// TransportPool, when set, shares the transports of the HTTP clients the
+	// SDK builds with other clients using the same pool and host. See
+	// TransportPool for the tradeoffs.
+	TransportPool *TransportPool
+
+	// DownloadConcurrency caps the downloads DownloadReplyAttachments runs
+	// at a time. Defaults to 4.
+	DownloadConcurrency int
+	// DownloadHTTPClient fetches the media of DownloadReplyAttachments, which
+	// is usually hosted outside EdgeServer. It defaults to a client of its
+	// own, without the ServerName override and the transport of HTTPClient.
+	DownloadHTTPClient *http.Client
+
+	// MessageTimeout, TriggerTimeout and UploadTimeout bound SendMessage,
+	// TriggerJSON and TriggerForm, and UploadBlob calls whose context has no
+	// deadline. Zero leaves them to HTTPClient's Timeout. Streams, including
+	// ReadMessages and TriggerJSONStream, are long-lived and never get one.
+	MessageTimeout time.Duration
+	TriggerTimeout time.Duration
+	UploadTimeout  time.Duration
+
+	// SSEPath is the path of the SSE endpoint NewStreamer connects to,
+	// relative to the bot provider, for EdgeServer versions that route
+	// streams elsewhere, e.g. "stream". Defaults to "message/sse".
+	SSEPath string
+
+	// StreamBufferSize is how many received events an SSE stream queues for
+	// Next. Defaults to 100. When the queue is full the connection stops
+	// reading until Next takes an event, so a slow consumer applies
+	// backpressure to the server rather than growing memory; streams with
+	// SnapshotSize drop their oldest queued event instead.
+	StreamBufferSize int
+	// StreamMaxTokenSize caps the size in bytes of one SSE event as read off
+	// the wire; larger events fail the stream. Defaults to 10MB. The read
+	// buffer starts at 1MB, or this size if smaller, and grows up to it.
+	StreamMaxTokenSize int
+
+	// SSEHTTPClient is used for SSE streams instead of HTTPClient. When it is
+	// nil, HTTPClient is reused for streams with its Timeout cleared; when both
+	// are nil a dedicated client is built from SSEDialTimeout and SSEKeepAlive.
+	SSEHTTPClient *http.Client
+	// SSEDialTimeout bounds connection establishment for the dedicated SSE
+	// client. Defaults to 10s.
+	SSEDialTimeout time.Duration
+	// SSEKeepAlive is the TCP keepalive period for the dedicated SSE client.
+	// Defaults to 30s; negative disables keepalives.
+	SSEKeepAlive time.Duration
+
+	// Transport selects how streams receive events. TransportSSE (the
+	// default) keeps one SSE connection open, read by a goroutine into a
+	// queue of StreamBufferSize events; TransportSSESync reads the same
+	// connection in Next instead, with no goroutine, queue or reconnects, so
+	// SSEMaxRetries, SSEResume, StreamBufferSize and SnapshotSize do not
+	// apply to it. TransportLongPoll polls the message/poll endpoint every
+	// PollInterval for up to MaxEventsPerPoll events, for networks whose
+	// proxies buffer SSE. The SSE* options do not apply to long polling.
+	Transport StreamTransport
+	// PollInterval is the wait between polls that returned no new events.
+	// Defaults to 1s.
+	PollInterval time.Duration
+	// MaxEventsPerPoll caps the events returned by one poll. Defaults to 100.
+	MaxEventsPerPoll int
+
+	// SSEMaxRetries is how many times a dropped SSE connection is
+	// re-established. 0 (the default) disables reconnection, negative retries
+	// until the context is done.
+	SSEMaxRetries int
+	// SSEResume sends the EventId of the last event received as
+	// Last-Event-ID when a stream reconnects, so EdgeServer can resume the run
+	// instead of starting over, and drops events whose EventId the stream has
+	// already received. Only useful with SSEMaxRetries set.
+	SSEResume bool
+	// OnReconnect, when set, is called before each SSE reconnection attempt,
+	// and before each AutoReconnect attempt, with the 1-based attempt number
+	// and the error that dropped the connection.
+	OnReconnect func(attempt int, lastErr error)
+	// AutoReconnect makes the streams of NewStreaming connect again when
+	// they fail with a transport error, e.g. once SSEMaxRetries is used up
+	// or for transports that do not reconnect, so Next keeps returning
+	// events. It is the number of consecutive attempts before the error is
+	// returned; negative retries until the context is done. Run errors, auth
+	// failures and malformed events are never retried. With SSEResume the
+	// new connection resumes from the last event via Last-Event-ID and
+	// replayed events are dropped; without it the message is sent again.
+	AutoReconnect int
+	// AutoReconnectDelay is the wait before the first AutoReconnect attempt,
+	// doubled on each consecutive attempt up to 30s. Defaults to 1s.
+	AutoReconnectDelay time.Duration
+
+	// DoneWhen, when set, is called with every event of a stream. When it
+	// returns true the event is still delivered, then the stream ends as if
+	// the run were done and the connection is closed. Use it to stop early,
+	// e.g. at the first MessageComplete. Runs on the stream's reader goroutine.
+	DoneWhen func(event *models.GenericBotSseEvent) bool
+	// MaxMessages, when positive, ends streams like DoneWhen once that many
+	// messages have completed, e.g. 1 for a preview of a long reply.
+	// CollectStream then returns the messages completed until then.
+	MaxMessages int
+
+	// SnapshotSize, when positive, makes streams keep their latest
+	// SnapshotSize events for SnapshotStreamer.Snapshot. Such streams never
+	// hold up the connection for a slow consumer: once the event queue is
+	// full, the oldest events not yet returned by Next are dropped. Only SSE
+	// streams support snapshots.
+	SnapshotSize int
+
+	// Metrics, when set, receives per-type event counts of every stream and
+	// the stream's totals when it is closed.
+	Metrics Metrics
+
+	// OnReferences, when set, is called as a stream discovers template
+	// references (citations) in its message events, with the references whose
+	// Uri the stream has not reported before. It runs on the stream's reader
+	// goroutine and should not block.
+	OnReferences func(refs []models.MessageTemplateReference)
+	// OnProcessLog, when set, is called with each process log line a stream
+	// receives, so backend processing can be watched while a run is in
+	// flight. The events are still delivered by Next. It runs on the
+	// stream's reader goroutine and should not block.
+	OnProcessLog func(processId, line string)
+
+	// VerifyEventOrder checks that streams receive events in lifecycle order
+	// (RunInit first, MessageStart before a message's deltas and completion,
+	// RunDone or RunError last) and logs or fails on violations, to catch
+	// server bugs and protocol drift. Off by default.
+	VerifyEventOrder EventOrderCheck
+
+	// DumpRawTo, when set, receives every SSE event of every stream as it
+	// arrives, before it is decoded, as "event:" and "data:" lines, so the
+	// frames behind decode failures can be inspected. It is for debugging
+	// only and must be safe for concurrent use when streams run in parallel.
+	// Long-poll streams are not dumped.
+	DumpRawTo io.Writer
+
+	// SkipMalformedEvents keeps a stream going when an individual event
+	// cannot be decoded. The event is dropped and reported to OnEventError
+	// instead of ending the stream; connection errors still end it.
+	SkipMalformedEvents bool
+	// OnEventError, when set, receives the decode errors of events skipped
+	// because of SkipMalformedEvents. It runs on the stream's reader
+	// goroutine and should not block.
+	OnEventError func(err error)
+
+	// FailoverEndpoints are tried in order after EdgeServerHost and
+	// BotProviderApiKey when a request cannot connect or is rejected with
+	// 401/403. Failover applies to SendMessage and TriggerJSON, whose bodies
+	// can be replayed; streamed uploads and SSE use the primary endpoint.
+	FailoverEndpoints []Endpoint
+	// OnEndpointUsed, when set, is called with the endpoint that served a
+	// failover-capable request.
+	OnEndpointUsed func(Endpoint)
+
+	// OnRequestStart, OnRequestComplete and OnRequestError, when set, are
+	// called for every API call and stream, with endpoint set to one of the
+	// HookEndpoint* names. OnRequestComplete fires once a response was
+	// received, whatever its status; for SSE streams it fires when the stream
+	// ends. OnRequestError fires whenever the operation fails, after
+	// OnRequestComplete if the failure came with a response.
+	OnRequestStart    func(endpoint string, ctx context.Context)
+	OnRequestComplete func(endpoint string, status int, dur time.Duration)
+	OnRequestError    func(endpoint string, err error)
+
+	// Tracer, when set, gets a span for every API call and stream, with the
+	// namespace, bot provider, endpoint and response status as attributes.
+	// Stream spans last as long as the stream and carry an event per event
+	// received. The HTTP requests of an operation are sent with the context
+	// returned by Tracer.Start, so a propagating transport can inject the
+	// span. See Tracer for plugging in OpenTelemetry.
+	Tracer Tracer
 }
 
 // NewBotProviderClient creates a BotProvider API client with default HTTP settings.
 func NewBotProviderClient(edgeServerHost, namespace, botProviderName, botProviderAPIKey string) Client {
 	return NewBotProviderClientWithConfig(&BotProviderConfig{
-		HTTPClient:        &http.Client{Timeout: defaultHTTPTimeout},
 		EdgeServerHost:    edgeServerHost,
 		Namespace:         namespace,
 		BotProviderName:   botProviderName,
@@ -53,8 +336,29 @@ func NewBotProviderClientWithConfig(config *BotProviderConfig) Client {
 	}
 
 	if config.HTTPClient == nil {
-		config.HTTPClient = &http.Client{Timeout: defaultHTTPTimeout}
+		config.HTTPClient = &http.Client{
+			Timeout: defaultHTTPTimeout,
+			Transport: config.TransportPool.transport(config, false, func() *http.Transport {
+				return newTransport(config)
+			}),
+		}
+		// The default client's Timeout would cut SSE streams short, so
+		// streaming gets its own client.
+		if config.SSEHTTPClient == nil {
+			config.SSEHTTPClient = newSSEHTTPClient(config)
+		}
 	}
 
-	return &BotProviderClient{config: config}
+	if config.DownloadHTTPClient == nil {
+		config.DownloadHTTPClient = newDownloadHTTPClient(config)
+	}
+
+	return &BotProviderClient{config: config, limiter: newSendLimiter(config)}
+}
+
+func newSendLimiter(config *BotProviderConfig) *priorityLimiter {
+	if config.SendRateLimit <= 0 {
+		return nil
+	}
+	return newPriorityLimiter(config.SendRateLimit, config.SendBurst)
 }
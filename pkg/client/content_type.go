@@ -0,0 +1,35 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// sniffLen is how much of a file net/http.DetectContentType looks at.
+const sniffLen = 512
+
+// fileContentType returns the Content-Type of a file part: mimeType when set,
+// otherwise the type detected from the first 512 bytes of reader. The
+// returned reader yields the whole file, detected bytes included, so nothing
+// is lost whether or not reader can seek.
+func fileContentType(reader io.Reader, mimeType *string) (io.Reader, string, error) {
+	if mimeType != nil && *mimeType != "" {
+		return reader, *mimeType, nil
+	}
+
+	prefix := make([]byte, sniffLen)
+	n, err := io.ReadFull(reader, prefix)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, "", fmt.Errorf("failed to read file data: %w", err)
+	}
+	prefix = prefix[:n]
+
+	contentType := "application/octet-stream"
+	if n > 0 {
+		contentType = http.DetectContentType(prefix)
+	}
+	return io.MultiReader(bytes.NewReader(prefix), reader), contentType, nil
+}
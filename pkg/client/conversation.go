@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// ConversationStreamer is a BotProviderStreamer that carries several turns.
+// Next returns false when the current turn ends; call Send to start the next
+// turn and keep iterating with the same streamer.
+//
+// EdgeServer has no bidirectional transport: it only streams
+// server-to-client, so each turn is its own SSE request. Whether a turn gets
+// a new connection is up to the HTTP client. A stream's request is
+// cancelled when the stream ends, so its connection is not necessarily
+// returned to the keep-alive pool for the next turn.
+type ConversationStreamer interface {
+	BotProviderStreamer
+	Send(message *models.GenericBotMessage) error
+}
+
+// conversationStream implements ConversationStreamer
+type conversationStream struct {
+	ctx     context.Context
+	config  *BotProviderConfig
+	current BotProviderStreamer
+	active  bool
+	closed  bool
+	mu      sync.Mutex
+}
+
+// NewConversationStreaming opens a conversation streamer with message as its first turn
+func NewConversationStreaming(ctx context.Context, config *BotProviderConfig, message *models.GenericBotMessage) (ConversationStreamer, error) {
+	s := &conversationStream{ctx: ctx, config: config}
+	if err := s.Send(message); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (c *BotProviderClient) NewConversationStreamer(ctx context.Context, message *models.GenericBotMessage) (ConversationStreamer, error) {
+	return NewConversationStreaming(ctx, c.config, message)
+}
+
+// Send starts the next turn. The previous turn must have ended, i.e. Next
+// must have returned false. A turn that failed does not end the
+// conversation: its error is reported by Err until the next Send.
+func (s *conversationStream) Send(message *models.GenericBotMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("conversation streamer is closed")
+	}
+	if s.active {
+		return fmt.Errorf("previous turn is still streaming")
+	}
+	if s.current != nil {
+		_ = s.current.Close()
+		s.current = nil
+	}
+
+	stream, err := NewStreaming(s.ctx, s.config, message)
+	if err != nil {
+		return err
+	}
+	s.current = stream
+	s.active = true
+	return nil
+}
+
+// Next advances within the current turn. Returns false once the turn ends.
+func (s *conversationStream) Next() bool {
+	s.mu.Lock()
+	stream, active := s.current, s.active
+	s.mu.Unlock()
+
+	if !active {
+		return false
+	}
+	if stream.Next() {
+		return true
+	}
+
+	s.mu.Lock()
+	s.active = false
+	s.mu.Unlock()
+	return false
+}
+
+// Current returns the current event of the current turn.
+func (s *conversationStream) Current() *models.GenericBotSseEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == nil {
+		return nil
+	}
+	return s.current.Current()
+}
+
+// Err returns the error of the current turn, if any.
+func (s *conversationStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == nil {
+		return nil
+	}
+	return s.current.Err()
+}
+
+// Close closes the current turn and rejects further sends.
+func (s *conversationStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.active = false
+	if s.current == nil {
+		return nil
+	}
+	return s.current.Close()
+}
@@ -0,0 +1,66 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/testutil"
+)
+
+func TestConversationContinuesAfterFailedTurn(t *testing.T) {
+	events := testutil.NewEventBuilder()
+	turns := [][]*models.GenericBotSseEvent{
+		{events.RunInit(), events.RunError(fullErrorDetail())},
+		{events.RunInit(), events.MessageComplete(models.BufferedMessage{MessageId: "m2", Text: "second"}), events.RunDone()},
+	}
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		turn := int(requests.Add(1)) - 1
+		if turn >= len(turns) {
+			http.Error(w, "unexpected turn", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range turns[turn] {
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "data: %s\n\n", data)
+		}
+		w.(http.Flusher).Flush()
+	}))
+	defer srv.Close()
+
+	conv, err := client.NewConversationStreaming(context.Background(), newStreamConfig(srv.URL, &http.Client{}), testMessage())
+	if err != nil {
+		t.Fatalf("NewConversationStreaming: %v", err)
+	}
+	defer conv.Close()
+
+	for conv.Next() {
+	}
+	if conv.Err() == nil {
+		t.Fatal("expected the first turn to fail")
+	}
+
+	if err := conv.Send(testMessage()); err != nil {
+		t.Fatalf("expected the next turn to start after a failed one, got %v", err)
+	}
+	var texts []string
+	for conv.Next() {
+		if fact := conv.Current().Fact.MessageComplete; fact != nil {
+			texts = append(texts, fact.Message.Text)
+		}
+	}
+	if err := conv.Err(); err != nil {
+		t.Fatalf("expected the second turn to succeed, got %v", err)
+	}
+	if len(texts) != 1 || texts[0] != "second" {
+		t.Fatalf("expected the second turn's message, got %v", texts)
+	}
+}
@@ -0,0 +1,22 @@
+package client
+
+import "go.asgard-ai.com/asgard-sdk-go/pkg/models"
+
+// newDonePredicate returns the stream's early-stop check, combining DoneWhen
+// and MaxMessages, or nil when neither is set. Its count is per stream, so
+// every stream gets its own predicate.
+func newDonePredicate(config *BotProviderConfig) func(event *models.GenericBotSseEvent) bool {
+	if config.DoneWhen == nil && config.MaxMessages <= 0 {
+		return nil
+	}
+
+	completed := 0
+	return func(event *models.GenericBotSseEvent) bool {
+		done := config.DoneWhen != nil && config.DoneWhen(event)
+		if config.MaxMessages > 0 && event.Fact.MessageComplete != nil {
+			completed++
+			done = done || completed >= config.MaxMessages
+		}
+		return done
+	}
+}
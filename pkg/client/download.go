@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+const defaultDownloadConcurrency = 4
+
+// DownloadedBlob is one media attachment of a reply fetched by
+// DownloadReplyAttachments.
+type DownloadedBlob struct {
+	URL   string
+	Type  models.ChatAttachmentType
+	Title string
+	// ContentType is the Content-Type the media was served with.
+	ContentType string
+	Data        []byte
+	// Err is the error of fetching this attachment.
+	Err error
+}
+
+// DownloadReplyAttachments fetches the media listed by ReplyAttachments, at
+// most DownloadConcurrency at a time. Media is usually served from outside
+// EdgeServer, so the requests are sent with DownloadHTTPClient and carry
+// neither the API key nor the configured headers. Per-download errors are in
+// DownloadedBlob.Err; the returned error is ctx's.
+func (c *BotProviderClient) DownloadReplyAttachments(ctx context.Context, reply *models.GenericBotReply) ([]DownloadedBlob, error) {
+	blobs := ReplyAttachments(reply)
+
+	concurrency := c.config.DownloadConcurrency
+	if concurrency < 1 {
+		concurrency = defaultDownloadConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range blobs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(blobs); j++ {
+				blobs[j].Err = ctx.Err()
+			}
+			wg.Wait()
+			return blobs, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(b *DownloadedBlob) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			b.ContentType, b.Data, b.Err = c.download(ctx, b.URL)
+		}(&blobs[i])
+	}
+
+	wg.Wait()
+	return blobs, ctx.Err()
+}
+
+func (c *BotProviderClient) download(ctx context.Context, u string) (string, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.config.DownloadHTTPClient.Do(req)
+	if err != nil {
+		return "", nil, requestError(ctx, "failed to download attachment", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", nil, fmt.Errorf("failed to download attachment %s: status %d", u, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, requestError(ctx, "failed to read attachment", err)
+	}
+	return resp.Header.Get("Content-Type"), data, nil
+}
+
+// ReplyAttachments lists, without fetching them, the media the templates of
+// reply refer to: images, videos, audio and carousel thumbnails, each URL
+// once, in the order models.ToChatMessages lists them.
+func ReplyAttachments(reply *models.GenericBotReply) []DownloadedBlob {
+	var blobs []DownloadedBlob
+	seen := make(map[string]struct{})
+	for _, msg := range models.ToChatMessages(reply) {
+		for _, a := range msg.Attachments {
+			switch a.Type {
+			case models.ChatAttachmentTypeImage, models.ChatAttachmentTypeVideo, models.ChatAttachmentTypeAudio:
+			default:
+				continue
+			}
+			if _, ok := seen[a.Url]; ok || a.Url == "" {
+				continue
+			}
+			seen[a.Url] = struct{}{}
+			blobs = append(blobs, DownloadedBlob{URL: a.Url, Type: a.Type, Title: a.Title})
+		}
+	}
+	return blobs
+}
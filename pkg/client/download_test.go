@@ -0,0 +1,47 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+func TestDownloadReplyAttachmentsBypassesEdgeServerClient(t *testing.T) {
+	media := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png"))
+	}))
+	defer media.Close()
+
+	edgeCalls := 0
+	c := client.NewBotProviderClientWithConfig(&client.BotProviderConfig{
+		EdgeServerHost:    "https://edge.invalid",
+		Namespace:         "default",
+		BotProviderName:   "test-bot",
+		BotProviderApiKey: "test-key",
+		ServerName:        "edge.example.com",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			edgeCalls++
+			return http.DefaultTransport.RoundTrip(r)
+		})},
+	})
+
+	url := media.URL + "/a.png"
+	reply := &models.GenericBotReply{Messages: []models.BufferedMessage{{
+		Template: &models.MessageTemplate{Type: models.MessageTemplateTypeImage, OriginalContentUrl: &url},
+	}}}
+	blobs, err := c.DownloadReplyAttachments(context.Background(), reply)
+	if err != nil {
+		t.Fatalf("DownloadReplyAttachments: %v", err)
+	}
+	if len(blobs) != 1 || blobs[0].Err != nil || string(blobs[0].Data) != "png" {
+		t.Fatalf("expected the image to be downloaded, got %+v", blobs)
+	}
+	if edgeCalls != 0 {
+		t.Fatalf("expected downloads not to go through the EdgeServer client, got %d requests", edgeCalls)
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+	"os"
+)
+
+// Environment variables read by ConfigFromEnv. They match the ones used by
+// edgeserver-cli.
+const (
+	EnvEdgeServerHost    = "EDGE_SERVER_HOST"
+	EnvNamespace         = "NAMESPACE"
+	EnvBotProviderName   = "BOT_PROVIDER_NAME"
+	EnvBotProviderAPIKey = "BOT_PROVIDER_API_KEY"
+)
+
+// Defaults ConfigFromEnv falls back to, the same as edgeserver-cli's.
+const (
+	defaultEnvEdgeServerHost  = "http://localhost:8080"
+	defaultEnvNamespace       = "default"
+	defaultEnvBotProviderName = "default-bot"
+)
+
+// ConfigFromEnv builds a config from the EDGE_SERVER_HOST, NAMESPACE,
+// BOT_PROVIDER_NAME and BOT_PROVIDER_API_KEY environment variables. Like
+// edgeserver-cli, unset variables default to http://localhost:8080, default
+// and default-bot; the API key has no default and is left empty.
+func ConfigFromEnv() *BotProviderConfig {
+	return &BotProviderConfig{
+		EdgeServerHost:    envOr(EnvEdgeServerHost, defaultEnvEdgeServerHost),
+		Namespace:         envOr(EnvNamespace, defaultEnvNamespace),
+		BotProviderName:   envOr(EnvBotProviderName, defaultEnvBotProviderName),
+		BotProviderApiKey: os.Getenv(EnvBotProviderAPIKey),
+	}
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// NewBotAgentFromEnv creates a BotAgent from ConfigFromEnv, failing when
+// BOT_PROVIDER_API_KEY is not set or the config does not validate.
+func NewBotAgentFromEnv() (BotAgent, error) {
+	config, err := validatedConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewBotAgentWithConfig(config), nil
+}
+
+// NewFunctionAgentFromEnv creates a FunctionAgent from ConfigFromEnv, failing
+// when BOT_PROVIDER_API_KEY is not set or the config does not validate.
+func NewFunctionAgentFromEnv() (FunctionAgent, error) {
+	config, err := validatedConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewFunctionAgentWithConfig(config), nil
+}
+
+func validatedConfigFromEnv() (*BotProviderConfig, error) {
+	if os.Getenv(EnvBotProviderAPIKey) == "" {
+		return nil, fmt.Errorf("missing environment variable: %s", EnvBotProviderAPIKey)
+	}
+
+	config := ConfigFromEnv()
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config from environment: %w", err)
+	}
+	return config, nil
+}
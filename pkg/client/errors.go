@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ErrNotSupported is wrapped by errors of operations the EdgeServer does not
+// implement.
+var ErrNotSupported = errors.New("operation not supported by EdgeServer")
+
+// ErrEmptyReply is returned by SendMessage, along with the reply, when
+// ErrorOnEmptyReply is set and a successful reply carries no messages.
+var ErrEmptyReply = errors.New("reply succeeded without messages")
+
+// requestError wraps a transport-level failure of action. When the failure
+// was caused by the context or by a network timeout, the result also wraps
+// context.Canceled or context.DeadlineExceeded, so callers can tell a
+// user-initiated cancel from a timeout with errors.Is.
+func requestError(ctx context.Context, action string, err error) error {
+	switch ctxErr := ctx.Err(); {
+	case errors.Is(ctxErr, context.Canceled):
+		return fmt.Errorf("%s: request canceled: %w", action, &contextError{ctxErr: context.Canceled, err: err})
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		return fmt.Errorf("%s: request deadline exceeded: %w", action, &contextError{ctxErr: context.DeadlineExceeded, err: err})
+	}
+
+	var netErr net.Error
+	if !errors.Is(err, context.DeadlineExceeded) && errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("%s: request timed out: %w", action, errors.Join(context.DeadlineExceeded, err))
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// contextError is the failure of a request whose context was done. It wraps
+// both the context's error and the transport failure it caused.
+type contextError struct {
+	ctxErr error
+	err    error
+}
+
+func (e *contextError) Error() string {
+	if e.err == nil {
+		return e.ctxErr.Error()
+	}
+	return e.err.Error()
+}
+
+func (e *contextError) Unwrap() []error {
+	if e.err == nil {
+		return []error{e.ctxErr}
+	}
+	return []error{e.ctxErr, e.err}
+}
+
+// APIError is returned when the EdgeServer answers a request with a non-OK
+// status, an unsuccessful envelope or a body that is not an envelope at all.
+// Use errors.As to inspect it, e.g. to tell a 401 from a 429.
+type APIError struct {
+	// Operation names the failed call, e.g. "trigger json".
+	Operation string
+	// Endpoint is the URL path of the failed request.
+	Endpoint   string
+	StatusCode int
+	// ErrorMessage and ErrorCode are the envelope's error and errorCode, when
+	// present.
+	ErrorMessage string
+	ErrorCode    string
+	// Body is the raw response body, truncated to MaxErrorBodyBytes.
+	Body string
+	// BodyTruncated reports whether Body was cut short.
+	BodyTruncated bool
+	// Err is the decode error when the body did not fit the envelope.
+	Err error
+}
+
+// Error implements the error interface for APIError
+func (e *APIError) Error() string {
+	var detail string
+	switch {
+	case e.Err != nil:
+		detail = fmt.Sprintf("failed to decode response: %v", e.Err)
+	case e.ErrorMessage != "" && e.ErrorCode != "":
+		detail = fmt.Sprintf("%s (%s)", e.ErrorMessage, e.ErrorCode)
+	case e.ErrorMessage != "":
+		detail = e.ErrorMessage
+	case e.ErrorCode != "":
+		detail = e.ErrorCode
+	default:
+		detail = "unknown error"
+	}
+
+	msg := fmt.Sprintf("%s failed (%d): %s", e.Operation, e.StatusCode, detail)
+	if e.Body != "" && (e.Err != nil || (e.ErrorMessage == "" && e.ErrorCode == "")) {
+		msg += fmt.Sprintf(": body=%q", e.Body)
+		if e.BodyTruncated {
+			msg += " (truncated)"
+		}
+	}
+	return msg
+}
+
+// Unwrap returns the decode error, if any.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Endpoint is an EdgeServer host and the bot provider API key to use with it.
+type Endpoint struct {
+	Host   string
+	APIKey string
+}
+
+// endpoints returns the primary endpoint followed by the configured failovers.
+func (c *BotProviderClient) endpoints() []Endpoint {
+	eps := make([]Endpoint, 0, 1+len(c.config.FailoverEndpoints))
+	eps = append(eps, Endpoint{Host: c.config.EdgeServerHost, APIKey: c.config.BotProviderApiKey})
+	return append(eps, c.config.FailoverEndpoints...)
+}
+
+// botProviderURL builds the URL of a bot provider endpoint on host.
+func (c *BotProviderClient) botProviderURL(host, suffix string) string {
+	return fmt.Sprintf("%s/ns/%s/bot-provider/%s/%s",
+		host,
+		url.PathEscape(c.config.Namespace),
+		url.PathEscape(c.config.BotProviderName),
+		suffix,
+	)
+}
+
+// doJSONWithFailover POSTs body to suffix on each endpoint in turn, moving on
+// when the connection fails or the endpoint rejects the API key. The response
+// of the first endpoint that answers otherwise is returned, and the last
+// endpoint's outcome is returned as-is. The configured and per-call headers
+// are set on every request after the API key; prepare, when non-nil, runs
+// after them.
+func (c *BotProviderClient) doJSONWithFailover(ctx context.Context, suffix, contentType string, body []byte, prepare func(*http.Request)) (*http.Response, error) {
+	opts := requestOptionsFromContext(ctx)
+	logger := loggerFor(ctx, c.config)
+	eps := c.endpoints()
+	for i, ep := range eps {
+		last := i == len(eps)-1
+
+		req, err := c.newJSONRequest(ctx, c.botProviderURL(ep.Host, suffix), contentType, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-API-KEY", ep.APIKey)
+		applyHeaders(req, c.config, opts)
+		if prepare != nil {
+			prepare(req)
+		}
+
+		resp, err := c.config.HTTPClient.Do(req)
+		if err != nil {
+			if last || ctx.Err() != nil {
+				return nil, err
+			}
+			logger.WithError(err).WithField("host", ep.Host).Warn("[EdgeServer] Endpoint unreachable, failing over")
+			continue
+		}
+
+		if !last && isAuthStatus(resp.StatusCode) {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			logger.WithField("host", ep.Host).Warnf("[EdgeServer] Endpoint rejected API key (%d), failing over", resp.StatusCode)
+			continue
+		}
+
+		if c.config.OnEndpointUsed != nil {
+			c.config.OnEndpointUsed(ep)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("no endpoints configured")
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// EventHandlers is an EventSink dispatching each event to the handler for its
+// type. Nil handlers are skipped; OnEvent, when set, runs first for every
+// event. Handlers run on the goroutine driving the stream, in event order, and
+// the first error a handler returns ends the stream.
+type EventHandlers struct {
+	OnEvent func(event *models.GenericBotSseEvent) error
+
+	OnRunInit              func(event *models.GenericBotSseEvent) error
+	OnRunDone              func(event *models.GenericBotSseEvent) error
+	OnProcessStart         func(fact *models.GenericBotSseEventFactProcessStart) error
+	OnProcessComplete      func(fact *models.GenericBotSseEventFactProcessComplete) error
+	OnProcessLog           func(fact *models.GenericBotSseEventFactProcessLog) error
+	OnMessageStart         func(message *models.BufferedMessage) error
+	OnMessageDelta         func(message *models.BufferedMessage) error
+	OnMessageComplete      func(message *models.BufferedMessage) error
+	OnToolCallStart        func(fact *models.GenericBotSseEventFactToolCallStart) error
+	OnToolCallComplete     func(fact *models.GenericBotSseEventFactToolCallComplete) error
+	OnCompletionModelUsage func(fact *models.GenericBotSseEventFactCompletionModelUsage) error
+
+	// OnRunError is called by StreamMessage when the run fails. Streams end on
+	// a run error instead of returning its event, so EventSink users get the
+	// detail from the stream error instead.
+	OnRunError func(detail *models.ErrorDetail) error
+}
+
+// Handle dispatches event to its handler.
+func (h EventHandlers) Handle(event *models.GenericBotSseEvent) error {
+	if h.OnEvent != nil {
+		if err := h.OnEvent(event); err != nil {
+			return err
+		}
+	}
+
+	f := event.Fact
+	switch {
+	case event.EventType == models.SseEventTypeRunInit:
+		return callHandler(h.OnRunInit, event)
+	case event.EventType == models.SseEventTypeRunDone:
+		return callHandler(h.OnRunDone, event)
+	case f.ProcessStart != nil:
+		return callHandler(h.OnProcessStart, f.ProcessStart)
+	case f.ProcessComplete != nil:
+		return callHandler(h.OnProcessComplete, f.ProcessComplete)
+	case f.ProcessLog != nil:
+		return callHandler(h.OnProcessLog, f.ProcessLog)
+	case f.MessageStart != nil:
+		return callHandler(h.OnMessageStart, &f.MessageStart.Message)
+	case f.MessageDelta != nil:
+		return callHandler(h.OnMessageDelta, &f.MessageDelta.Message)
+	case f.MessageComplete != nil:
+		return callHandler(h.OnMessageComplete, &f.MessageComplete.Message)
+	case f.ToolCallStart != nil:
+		return callHandler(h.OnToolCallStart, f.ToolCallStart)
+	case f.ToolCallComplete != nil:
+		return callHandler(h.OnToolCallComplete, f.ToolCallComplete)
+	case f.CompletionModelUsage != nil:
+		return callHandler(h.OnCompletionModelUsage, f.CompletionModelUsage)
+	}
+	return nil
+}
+
+func callHandler[T any](handler func(T) error, arg T) error {
+	if handler == nil {
+		return nil
+	}
+	return handler(arg)
+}
+
+// StreamMessage streams the reply to message through agent and dispatches
+// its events to handlers until the run ends. It returns the first handler
+// error or the stream error. A run error is passed to OnRunError, whose error,
+// if any, is returned instead of the run's *ErrorDetail. The stream is closed
+// on return.
+func StreamMessage(ctx context.Context, agent BotAgent, message *models.GenericBotMessage, handlers EventHandlers) error {
+	err := agent.RunWithSink(ctx, message, handlers)
+
+	var detail *models.ErrorDetail
+	if handlers.OnRunError != nil && errors.As(err, &detail) {
+		if handlerErr := handlers.OnRunError(detail); handlerErr != nil {
+			return handlerErr
+		}
+	}
+	return err
+}
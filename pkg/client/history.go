@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// GetChannelHistory returns one page of up to limit messages of
+// customChannelID, oldest first: the page is sorted by Idx, with messages
+// without an Idx after the indexed ones in the order the server sent them. A
+// non-positive limit leaves the page size to the server. before is a cursor:
+// the server returns the messages sent before the message with that
+// MessageId, and a nil or empty before starts from the newest message. To
+// fetch the next page, pass the MessageId of the first message of the current
+// page as before. An empty page means there is no older history.
+func (c *BotProviderClient) GetChannelHistory(ctx context.Context, customChannelID string, limit int, before *string) (_ []models.BufferedMessage, err error) {
+	if customChannelID == "" {
+		return nil, fmt.Errorf("customChannelID cannot be empty")
+	}
+
+	ctx, hook := startRequest(ctx, c.config, HookEndpointHistory)
+	defer func() { hook.done(err) }()
+
+	query := url.Values{"customChannelId": {outgoingChannelID(c.config, customChannelID)}}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if before != nil && *before != "" {
+		query.Set("before", *before)
+	}
+	u := c.botProviderURL(c.config.EdgeServerHost, "message/history") + "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	applyRequestOverrides(req, c.config)
+	req.Header.Set("X-API-KEY", c.config.BotProviderApiKey)
+	applyHeaders(req, c.config, requestOptionsFromContext(ctx))
+
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, requestError(ctx, "failed to get channel history", err)
+	}
+	defer resp.Body.Close()
+	hook.status = resp.StatusCode
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, requestError(ctx, "failed to read response body", err)
+	}
+
+	payload, err := decodeResponse[[]models.BufferedMessage](c.config, "get channel history", resp, respBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := payload.Data
+	if messages == nil {
+		messages = []models.BufferedMessage{}
+	}
+	models.SortMessagesByIdx(messages)
+	for i := range messages {
+		guardMessageText(&messages[i], c.config.MaxMessageTextBytes, c.config.OnMessageTextTruncated)
+	}
+	return messages, nil
+}
@@ -0,0 +1,40 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetChannelHistoryOldestFirst(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"isSuccess":true,"data":[
+			{"messageId":"m3","idx":3},
+			{"messageId":"none"},
+			{"messageId":"m1","idx":1},
+			{"messageId":"m2","idx":2}
+		]}`))
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv.URL)
+
+	messages, err := c.GetChannelHistory(context.Background(), "ch", 10, nil)
+	if err != nil {
+		t.Fatalf("GetChannelHistory: %v", err)
+	}
+	var ids []string
+	for _, msg := range messages {
+		ids = append(ids, msg.MessageId)
+	}
+	want := []string{"m1", "m2", "m3", "none"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, ids)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Endpoint names passed to the request lifecycle hooks.
+const (
+	HookEndpointMessage       = "message"
+	HookEndpointSSE           = "message/sse"
+	HookEndpointPoll          = "message/poll"
+	HookEndpointHistory       = "message/history"
+	HookEndpointJSON          = "json"
+	HookEndpointJSONSSE       = "json/sse"
+	HookEndpointForm          = "form"
+	HookEndpointBlob          = "blob"
+	HookEndpointCancelProcess = "process/cancel"
+	HookEndpointCancelRun     = "message/cancel"
+	HookEndpointPing          = "ping"
+	HookEndpointVerifyAPIKey  = "verify"
+)
+
+// requestHook tracks one operation for the config's lifecycle hooks and
+// Tracer.
+type requestHook struct {
+	config   *BotProviderConfig
+	endpoint string
+	start    time.Time
+	status   int
+	span     Span
+}
+
+// startRequest fires OnRequestStart, starts the operation's span and begins
+// timing it. The returned context carries the span; the operation sends its
+// requests with it.
+func startRequest(ctx context.Context, config *BotProviderConfig, endpoint string) (context.Context, *requestHook) {
+	if config.OnRequestStart != nil {
+		config.OnRequestStart(endpoint, ctx)
+	}
+	ctx, span := startSpan(ctx, config, endpoint)
+	return ctx, &requestHook{config: config, endpoint: endpoint, start: time.Now(), span: span}
+}
+
+// event records a stream event on the operation's span.
+func (h *requestHook) event(eventType string) {
+	if h.span != nil {
+		h.span.AddEvent(eventType, nil)
+	}
+}
+
+// done fires OnRequestComplete when a response was received, with its status,
+// and OnRequestError when the operation failed, and ends the span.
+func (h *requestHook) done(err error) {
+	status := h.status
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	}
+
+	if status != 0 && h.config.OnRequestComplete != nil {
+		h.config.OnRequestComplete(h.endpoint, status, time.Since(h.start))
+	}
+	if err != nil && h.config.OnRequestError != nil {
+		h.config.OnRequestError(h.endpoint, err)
+	}
+
+	if h.span != nil {
+		if status != 0 {
+			h.span.SetAttribute(SpanAttrStatusCode, status)
+		}
+		if err != nil {
+			h.span.RecordError(err)
+		}
+		h.span.End()
+	}
+}
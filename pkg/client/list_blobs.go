@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// ListBlobs returns the metadata of the blobs previously uploaded to
+// customChannelID, e.g. to restore a conversation's attachments after a
+// restart. A channel without blobs yields an empty slice, not an error.
+func (c *BotProviderClient) ListBlobs(ctx context.Context, customChannelID string) (_ []models.Blob, err error) {
+	ctx, hook := startRequest(ctx, c.config, HookEndpointBlob)
+	defer func() { hook.done(err) }()
+
+	query := url.Values{"customChannelId": {outgoingChannelID(c.config, customChannelID)}}
+	u := c.botProviderURL(c.config.EdgeServerHost, "blob") + "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	applyRequestOverrides(req, c.config)
+	req.Header.Set("X-API-KEY", c.config.BotProviderApiKey)
+	applyHeaders(req, c.config, requestOptionsFromContext(ctx))
+
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, requestError(ctx, "failed to list blobs", err)
+	}
+	defer resp.Body.Close()
+	hook.status = resp.StatusCode
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, requestError(ctx, "failed to read response body", err)
+	}
+
+	payload, err := decodeResponse[[]models.Blob](c.config, "list blobs", resp, respBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Data == nil {
+		return []models.Blob{}, nil
+	}
+	return payload.Data, nil
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger. Requests and streams made
+// with the context log through it, so request-scoped fields end up on the
+// SDK's log lines too.
+func WithLogger(ctx context.Context, logger log.FieldLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// loggerFor returns the logger carried by ctx, falling back to the config
+// logger and then to the logrus standard logger.
+func loggerFor(ctx context.Context, config *BotProviderConfig) log.FieldLogger {
+	if logger, ok := ctx.Value(loggerKey{}).(log.FieldLogger); ok && logger != nil {
+		return logger
+	}
+	if config != nil && config.Logger != nil {
+		return config.Logger
+	}
+	return log.StandardLogger()
+}
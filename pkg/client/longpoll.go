@@ -0,0 +1,299 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// StreamTransport selects how NewStreaming receives run events.
+type StreamTransport string
+
+const (
+	// TransportSSE streams events over a single SSE connection (the default).
+	TransportSSE StreamTransport = "sse"
+	// TransportSSESync reads the SSE connection directly in Next, without
+	// the goroutine and event queue of TransportSSE. It does not reconnect.
+	TransportSSESync StreamTransport = "sse-sync"
+	// TransportLongPoll polls the EdgeServer for events instead, for networks
+	// whose proxies buffer SSE responses.
+	TransportLongPoll StreamTransport = "longpoll"
+)
+
+const (
+	defaultPollInterval     = time.Second
+	defaultMaxEventsPerPoll = 100
+)
+
+// pollResult is the data of a message/poll response.
+type pollResult struct {
+	RequestId string                      `json:"requestId"`
+	Events    []models.GenericBotSseEvent `json:"events"`
+}
+
+// longPollStream implements BotProviderStreamer on top of the message/poll
+// endpoint. The first poll POSTs the message and starts the run; later polls
+// fetch the events after the last EventId seen.
+type longPollStream struct {
+	ctx          context.Context
+	connCtx      context.Context
+	cancel       context.CancelFunc
+	client       *BotProviderClient
+	logger       log.FieldLogger
+	message      *models.GenericBotMessage
+	requestID    string
+	knownID      atomic.Value
+	lastEventID  string
+	started      bool
+	backlog      bool
+	pending      []models.GenericBotSseEvent
+	currentEvent *models.GenericBotSseEvent
+	err          error
+	done         bool
+	closed       bool
+	references   *referenceTracker
+	counter      *eventCounter
+	order        *eventOrderChecker
+	doneWhen     func(event *models.GenericBotSseEvent) bool
+	mu           sync.Mutex
+}
+
+func newLongPollStream(ctx context.Context, config *BotProviderConfig, message *models.GenericBotMessage) (BotProviderStreamer, error) {
+	if config.HTTPClient == nil {
+		config = cloneConfig(config)
+		config.HTTPClient = &http.Client{
+			Timeout:   defaultHTTPTimeout,
+			Transport: newTransport(config),
+		}
+	}
+
+	s := &longPollStream{
+		ctx:        ctx,
+		client:     &BotProviderClient{config: config},
+		logger:     loggerFor(ctx, config),
+		message:    message,
+		references: newReferenceTracker(config),
+		counter:    newEventCounter(config),
+	}
+	s.order = newEventOrderChecker(config, s.logger)
+	s.doneWhen = newDonePredicate(config)
+	s.connCtx, s.cancel = context.WithCancel(ctx)
+
+	// The first poll starts the run, so a message the server rejects fails
+	// here like an SSE connection would.
+	if err := s.poll(); err != nil {
+		s.cancel()
+		return nil, fmt.Errorf("failed to start long-poll stream: %w", err)
+	}
+	return s, nil
+}
+
+// poll fetches the next batch of events into pending.
+func (s *longPollStream) poll() (err error) {
+	config := s.client.config
+	ctx, hook := startRequest(s.connCtx, config, HookEndpointPoll)
+	defer func() { hook.done(err) }()
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(maxEventsPerPoll(config)))
+
+	var req *http.Request
+	if !s.started {
+		body, marshalErr := json.Marshal(outgoingMessage(config, s.message))
+		if marshalErr != nil {
+			return fmt.Errorf("failed to marshal bot message: %w", marshalErr)
+		}
+		u := s.client.botProviderURL(config.EdgeServerHost, "message/poll?"+query.Encode())
+		req, err = s.client.newJSONRequest(ctx, u, defaultJSONContentType, body)
+	} else {
+		query.Set("requestId", s.requestID)
+		if s.lastEventID != "" {
+			query.Set("after", s.lastEventID)
+		}
+		u := s.client.botProviderURL(config.EdgeServerHost, "message/poll?"+query.Encode())
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create poll request: %w", err)
+	}
+	applyRequestOverrides(req, config)
+
+	req.Header.Set("X-API-KEY", config.BotProviderApiKey)
+	applyHeaders(req, config, requestOptionsFromContext(s.ctx))
+
+	resp, err := config.HTTPClient.Do(req)
+	if err != nil {
+		return requestError(s.ctx, "failed to poll events", err)
+	}
+	defer resp.Body.Close()
+	hook.status = resp.StatusCode
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return requestError(s.ctx, "failed to read response body", err)
+	}
+
+	payload, err := decodeResponse[pollResult](config, "poll events", resp, respBytes)
+	if err != nil {
+		return err
+	}
+
+	s.started = true
+	// A full batch means more events are likely waiting already.
+	s.backlog = len(payload.Data.Events) >= maxEventsPerPoll(config)
+	if payload.Data.RequestId != "" {
+		s.requestID = payload.Data.RequestId
+	}
+	if n := len(payload.Data.Events); n > 0 {
+		s.lastEventID = payload.Data.Events[n-1].EventId
+		if s.requestID == "" {
+			s.requestID = payload.Data.Events[0].RequestId
+		}
+	}
+	if s.requestID != "" {
+		// Next holds mu while polling, so RequestID reads a copy.
+		s.knownID.Store(s.requestID)
+	}
+	for i := range payload.Data.Events {
+		hook.event(string(payload.Data.Events[i].EventType))
+		s.references.observe(&payload.Data.Events[i])
+		reportProcessLog(s.client.config, &payload.Data.Events[i])
+		s.counter.observe(&payload.Data.Events[i])
+	}
+	s.pending = append(s.pending, payload.Data.Events...)
+
+	s.logger.WithFields(log.Fields{
+		"request_id": s.requestID,
+		"events":     len(payload.Data.Events),
+	}).Debug("[EdgeServer] Polled events")
+	return nil
+}
+
+// Next advances to the next event, polling every PollInterval while none are
+// pending. Returns false if there are no more events or an error occurred.
+func (s *longPollStream) Next() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || s.err != nil {
+		return false
+	}
+
+	for len(s.pending) == 0 {
+		if s.done {
+			return false
+		}
+
+		if !s.backlog {
+			timer := time.NewTimer(pollInterval(s.client.config))
+			select {
+			case <-timer.C:
+			case <-s.connCtx.Done():
+				timer.Stop()
+				s.err = s.ctx.Err()
+				return false
+			}
+		}
+
+		if err := s.poll(); err != nil {
+			if s.closed || s.connCtx.Err() != nil {
+				s.err = s.ctx.Err()
+				return false
+			}
+			s.err = err
+			return false
+		}
+	}
+
+	ev := s.pending[0]
+	s.pending = s.pending[1:]
+
+	if err := s.order.check(&ev); err != nil {
+		s.done = true
+		s.err = err
+		return false
+	}
+
+	switch ev.EventType {
+	case models.SseEventTypeRunError:
+		s.done = true
+		s.err = fmt.Errorf("SSE stream error: %w", runErrorDetail(&ev))
+		return false
+	case models.SseEventTypeRunDone:
+		s.done = true
+	}
+	if s.doneWhen != nil && s.doneWhen(&ev) {
+		s.done = true
+		s.pending = nil
+	}
+
+	s.currentEvent = &ev
+	return true
+}
+
+// Current returns the current event. Should only be called after Next() returns true.
+func (s *longPollStream) Current() *models.GenericBotSseEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.currentEvent
+}
+
+// Err returns any error that occurred during polling
+func (s *longPollStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close stops polling and cleans up resources
+func (s *longPollStream) Close() error {
+	// Cancel before locking: Next holds the lock while it waits to poll.
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.counter.report()
+	}
+	s.closed = true
+	s.pending = nil
+	s.currentEvent = nil
+	return nil
+}
+
+// RequestID returns the RequestId of the run, once a poll has reported it.
+func (s *longPollStream) RequestID() string {
+	id, _ := s.knownID.Load().(string)
+	return id
+}
+
+func pollInterval(config *BotProviderConfig) time.Duration {
+	if config.PollInterval > 0 {
+		return config.PollInterval
+	}
+	return defaultPollInterval
+}
+
+func maxEventsPerPoll(config *BotProviderConfig) int {
+	if config.MaxEventsPerPoll > 0 {
+		return config.MaxEventsPerPoll
+	}
+	return defaultMaxEventsPerPoll
+}
+
+// cloneConfig returns a shallow copy of config.
+func cloneConfig(config *BotProviderConfig) *BotProviderConfig {
+	clone := *config
+	return &clone
+}
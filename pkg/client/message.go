@@ -0,0 +1,56 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// prepareMessage applies config-driven defaults to an outgoing message before
+// it is marshalled.
+func prepareMessage(config *BotProviderConfig, message *models.GenericBotMessage) error {
+	if config.AutoMessageID && message.CustomMessageId == "" {
+		id, err := newMessageID()
+		if err != nil {
+			return err
+		}
+		// Written back so callers can correlate the reply with the generated ID.
+		message.CustomMessageId = id
+	}
+	if config.MessageInterceptor != nil {
+		if err := config.MessageInterceptor(message); err != nil {
+			return fmt.Errorf("message rejected by interceptor: %w", err)
+		}
+	}
+	return nil
+}
+
+// outgoingMessage returns message as it goes on the wire. When the config has
+// a ChannelIDTransformer the result is a copy carrying the transformed channel
+// ID, so the caller's message keeps its own.
+func outgoingMessage(config *BotProviderConfig, message *models.GenericBotMessage) *models.GenericBotMessage {
+	if config.ChannelIDTransformer == nil {
+		return message
+	}
+	out := *message
+	out.CustomChannelId = config.ChannelIDTransformer(message.CustomChannelId)
+	return &out
+}
+
+// outgoingChannelID returns channelID as it goes on the wire.
+func outgoingChannelID(config *BotProviderConfig, channelID string) string {
+	if config.ChannelIDTransformer == nil {
+		return channelID
+	}
+	return config.ChannelIDTransformer(channelID)
+}
+
+func newMessageID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate message id: %w", err)
+	}
+	return "sdk-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import "go.asgard-ai.com/asgard-sdk-go/pkg/models"
+
+// NewMessageAccumulator returns a MessageReader over the messages of stream,
+// each yielded once its MessageComplete arrives with the text of its deltas
+// assembled by a StreamAccumulator with the default CollectOptions, so
+// interleaved messages are kept apart by MessageId. Messages are yielded in
+// the order they complete; ones that never complete are not yielded. Once
+// the stream ends Err returns its error, which for a failed run wraps the
+// run's *models.ErrorDetail. Close closes stream.
+func NewMessageAccumulator(stream BotProviderStreamer) MessageReader {
+	return &messageAccumulator{stream: stream, acc: NewStreamAccumulator(CollectOptions{})}
+}
+
+type messageAccumulator struct {
+	stream  BotProviderStreamer
+	acc     *StreamAccumulator
+	current *models.BufferedMessage
+}
+
+func (m *messageAccumulator) Next() bool {
+	for m.stream.Next() {
+		ev := m.stream.Current()
+		m.acc.Add(ev)
+		if ev.Fact.MessageComplete != nil {
+			messages := m.acc.reply.Messages
+			msg := messages[len(messages)-1]
+			m.current = &msg
+			return true
+		}
+	}
+	m.current = nil
+	return false
+}
+
+func (m *messageAccumulator) Current() *models.BufferedMessage {
+	return m.current
+}
+
+func (m *messageAccumulator) Err() error {
+	return m.stream.Err()
+}
+
+func (m *messageAccumulator) Close() error {
+	return m.stream.Close()
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// Metrics receives per-stream event counts, e.g. to feed Prometheus counters
+// and histograms. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// IncEvent is called for every event a stream receives. It runs on the
+	// stream's reader goroutine and should not block.
+	IncEvent(eventType models.SseEventType)
+	// ObserveRunEvents is called once per stream when it is closed, with the
+	// total number of events received and the count per event type.
+	ObserveRunEvents(total int, byType map[models.SseEventType]int)
+}
+
+// eventCounter tallies a stream's events for Metrics.
+type eventCounter struct {
+	metrics  Metrics
+	mu       sync.Mutex
+	total    int
+	byType   map[models.SseEventType]int
+	reported bool
+}
+
+func newEventCounter(config *BotProviderConfig) *eventCounter {
+	if config.Metrics == nil {
+		return nil
+	}
+	return &eventCounter{metrics: config.Metrics, byType: make(map[models.SseEventType]int)}
+}
+
+func (c *eventCounter) observe(event *models.GenericBotSseEvent) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.total++
+	c.byType[event.EventType]++
+	c.mu.Unlock()
+	c.metrics.IncEvent(event.EventType)
+}
+
+// report passes the totals to ObserveRunEvents the first time it is called.
+func (c *eventCounter) report() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	if c.reported {
+		c.mu.Unlock()
+		return
+	}
+	c.reported = true
+	byType := make(map[models.SseEventType]int, len(c.byType))
+	for k, v := range c.byType {
+		byType[k] = v
+	}
+	total := c.total
+	c.mu.Unlock()
+
+	c.metrics.ObserveRunEvents(total, byType)
+}
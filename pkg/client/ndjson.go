@@ -0,0 +1,213 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+const ndjsonMaxLineSize = 10 * 1024 * 1024
+
+// MessageReader iterates over the messages of a REST reply as they arrive.
+type MessageReader interface {
+	Next() bool
+	Current() *models.BufferedMessage
+	Err() error
+	Close() error
+}
+
+// ReadMessages sends message to the REST endpoint and returns its reply
+// messages one at a time. When the EdgeServer answers with NDJSON each line is
+// decoded as it arrives; a regular JSON envelope is decoded in full and its
+// messages are iterated over.
+func (c *BotProviderClient) ReadMessages(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (_ MessageReader, err error) {
+	if message == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+	if err := prepareMessage(c.config, message); err != nil {
+		return nil, err
+	}
+
+	ctx, hook := startRequest(ctx, c.config, HookEndpointMessage)
+	defer func() {
+		if hook != nil {
+			hook.done(err)
+		}
+	}()
+
+	suffix := "message"
+	if isDebug {
+		suffix = "message?is_debug=true"
+	}
+
+	body, err := json.Marshal(outgoingMessage(c.config, message))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	resp, err := c.doJSONWithFailover(ctx, suffix, defaultJSONContentType, body, func(req *http.Request) {
+		req.Header.Set("Accept", "application/x-ndjson, application/json")
+	})
+	if err != nil {
+		return nil, requestError(ctx, "failed to send message", err)
+	}
+	hook.status = resp.StatusCode
+
+	if resp.StatusCode == http.StatusOK && isNDJSON(resp.Header.Get("Content-Type")) {
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), ndjsonMaxLineSize)
+		reader := &ndjsonReader{ctx: ctx, config: c.config, body: resp.Body, scanner: scanner, hook: hook}
+		// The request lasts until the stream ends; the reader reports it.
+		hook = nil
+		return reader, nil
+	}
+
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, requestError(ctx, "failed to read response body", err)
+	}
+
+	payload, err := decodeResponse[models.GenericBotReply](c.config, "send message", resp, respBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range payload.Data.Messages {
+		guardMessageText(&payload.Data.Messages[i], c.config.MaxMessageTextBytes, c.config.OnMessageTextTruncated)
+	}
+
+	return &sliceMessageReader{messages: payload.Data.Messages}, nil
+}
+
+func isNDJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return true
+	}
+	return false
+}
+
+// ndjsonReader decodes one BufferedMessage per line of a streamed body.
+type ndjsonReader struct {
+	ctx     context.Context
+	config  *BotProviderConfig
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+	current *models.BufferedMessage
+	err     error
+	done    bool
+	closed  bool
+	hook    *requestHook
+	mu      sync.Mutex
+}
+
+func (r *ndjsonReader) Next() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed || r.done {
+		return false
+	}
+
+	for r.scanner.Scan() {
+		line := r.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var msg models.BufferedMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			r.finish(fmt.Errorf("failed to decode message line: %w", err))
+			return false
+		}
+		guardMessageText(&msg, r.config.MaxMessageTextBytes, r.config.OnMessageTextTruncated)
+		r.current = &msg
+		return true
+	}
+
+	var err error
+	if scanErr := r.scanner.Err(); scanErr != nil {
+		err = requestError(r.ctx, "failed to read message stream", scanErr)
+	}
+	r.finish(err)
+	return false
+}
+
+// finish ends the stream with err, which is nil once the body was read in
+// full, and reports the end of the request. r.mu must be held.
+func (r *ndjsonReader) finish(err error) {
+	r.done = true
+	r.err = err
+	r.hook.done(err)
+}
+
+func (r *ndjsonReader) Current() *models.BufferedMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+func (r *ndjsonReader) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *ndjsonReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	r.current = nil
+	if !r.done {
+		r.done = true
+		r.hook.done(nil)
+	}
+	return r.body.Close()
+}
+
+// sliceMessageReader iterates over the messages of an already decoded reply.
+type sliceMessageReader struct {
+	messages []models.BufferedMessage
+	pos      int
+	current  *models.BufferedMessage
+}
+
+func (r *sliceMessageReader) Next() bool {
+	if r.pos >= len(r.messages) {
+		r.current = nil
+		return false
+	}
+	r.current = &r.messages[r.pos]
+	r.pos++
+	return true
+}
+
+func (r *sliceMessageReader) Current() *models.BufferedMessage {
+	return r.current
+}
+
+func (r *sliceMessageReader) Err() error {
+	return nil
+}
+
+func (r *sliceMessageReader) Close() error {
+	r.pos = len(r.messages)
+	return nil
+}
@@ -0,0 +1,69 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+)
+
+func TestReadMessagesReportsEndOfStream(t *testing.T) {
+	const stall = 100 * time.Millisecond
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"messageId":"m1","text":"first"}`)
+		w.(http.Flusher).Flush()
+		time.Sleep(stall)
+		fmt.Fprintln(w, `{"messageId":`)
+	}))
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var durations []time.Duration
+	var errs []error
+	config := newStreamConfig(srv.URL, &http.Client{})
+	config.OnRequestComplete = func(endpoint string, status int, dur time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		durations = append(durations, dur)
+	}
+	config.OnRequestError = func(endpoint string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+	c := client.NewBotProviderClientWithConfig(config)
+
+	reader, err := c.ReadMessages(context.Background(), testMessage(), false)
+	if err != nil {
+		t.Fatalf("ReadMessages: %v", err)
+	}
+	defer reader.Close()
+
+	mu.Lock()
+	reported := len(durations) + len(errs)
+	mu.Unlock()
+	if reported != 0 {
+		t.Fatal("expected the request not to be reported before the stream ends")
+	}
+
+	for reader.Next() {
+	}
+	if reader.Err() == nil {
+		t.Fatal("expected the malformed line to fail the stream")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(durations) != 1 || durations[0] < stall {
+		t.Fatalf("expected one completion lasting the whole stream, got %v", durations)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected the stream error to be reported once, got %v", errs)
+	}
+}
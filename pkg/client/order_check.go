@@ -0,0 +1,113 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// EventOrderCheck selects what a stream does when EdgeServer sends events out
+// of their lifecycle order.
+type EventOrderCheck int
+
+const (
+	// EventOrderCheckOff does not check event order. This is the default.
+	EventOrderCheckOff EventOrderCheck = iota
+	// EventOrderCheckWarn logs each violation and keeps the stream going.
+	EventOrderCheckWarn
+	// EventOrderCheckError ends the stream with an error wrapping
+	// ErrEventOrder at the first violation.
+	EventOrderCheckError
+)
+
+// ErrEventOrder is wrapped by the error of a stream that received events out
+// of order with EventOrderCheckError.
+var ErrEventOrder = errors.New("SSE events out of order")
+
+// eventOrderChecker verifies the lifecycle order of a stream's events: the
+// run starts with RunInit, a message starts with MessageStart before its
+// deltas and completion, and nothing follows RunDone or RunError.
+type eventOrderChecker struct {
+	mode     EventOrderCheck
+	logger   log.FieldLogger
+	started  bool
+	ended    bool
+	messages map[string]bool
+}
+
+func newEventOrderChecker(config *BotProviderConfig, logger log.FieldLogger) *eventOrderChecker {
+	if config.VerifyEventOrder == EventOrderCheckOff {
+		return nil
+	}
+	return &eventOrderChecker{mode: config.VerifyEventOrder, logger: logger, messages: make(map[string]bool)}
+}
+
+// check records event and reports a violation as an error in
+// EventOrderCheckError mode; warnings are logged and return nil.
+func (c *eventOrderChecker) check(event *models.GenericBotSseEvent) error {
+	if c == nil {
+		return nil
+	}
+	violation := c.observe(event)
+	if violation == "" {
+		return nil
+	}
+	if c.mode == EventOrderCheckError {
+		return fmt.Errorf("%w: %s", ErrEventOrder, violation)
+	}
+	c.logger.WithFields(log.Fields{
+		"event_type": event.EventType,
+		"event_id":   event.EventId,
+	}).Warn("[EdgeServer] SSE event out of order: " + violation)
+	return nil
+}
+
+func (c *eventOrderChecker) observe(event *models.GenericBotSseEvent) string {
+	if c.ended {
+		return fmt.Sprintf("%s after the run ended", event.EventType)
+	}
+
+	switch event.EventType {
+	case models.SseEventTypeRunInit:
+		if c.started {
+			return "run init after the run started"
+		}
+		c.started = true
+		return ""
+	case models.SseEventTypeRunDone, models.SseEventTypeRunError:
+		c.ended = true
+	}
+
+	if !c.started {
+		c.started = true
+		return fmt.Sprintf("%s before run init", event.EventType)
+	}
+
+	f := event.Fact
+	switch {
+	case f.MessageStart != nil:
+		id := f.MessageStart.Message.MessageId
+		if _, seen := c.messages[id]; seen {
+			return fmt.Sprintf("message %q started twice", id)
+		}
+		c.messages[id] = true
+	case f.MessageDelta != nil:
+		id := f.MessageDelta.Message.MessageId
+		if open, seen := c.messages[id]; !seen {
+			return fmt.Sprintf("delta of message %q before its start", id)
+		} else if !open {
+			return fmt.Sprintf("delta of message %q after its completion", id)
+		}
+	case f.MessageComplete != nil:
+		id := f.MessageComplete.Message.MessageId
+		if open, seen := c.messages[id]; !seen {
+			return fmt.Sprintf("completion of message %q before its start", id)
+		} else if !open {
+			return fmt.Sprintf("message %q completed twice", id)
+		}
+		c.messages[id] = false
+	}
+	return ""
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+const defaultOutboxInterval = 5 * time.Second
+
+// ErrQueuedInOutbox is wrapped, along with the send error, by the error
+// SendMessage returns when a failed message was stored in the Outbox for
+// OutboxWorker to retry.
+var ErrQueuedInOutbox = errors.New("message queued in outbox for retry")
+
+// OutboxEntry is a failed SendMessage call waiting in an OutboxStore. Message
+// is stored after AutoMessageID and MessageInterceptor were applied, and is
+// resent as is.
+type OutboxEntry struct {
+	Message  models.GenericBotMessage `json:"message"`
+	IsDebug  bool                     `json:"isDebug"`
+	QueuedAt time.Time                `json:"queuedAt"`
+	// Error is the error of the failed call.
+	Error string `json:"error"`
+}
+
+// OutboxStore is the queue behind the Outbox. Implement it on a durable
+// backend, e.g. a database table, for messages to survive restarts; it must
+// be safe for concurrent use.
+type OutboxStore interface {
+	// Put appends entry to the queue.
+	Put(ctx context.Context, entry OutboxEntry) error
+	// Pop calls fn with the oldest entry and removes the entry once fn
+	// returns nil. An fn error leaves the entry at the head of the queue and
+	// is returned. Pop reports false, without calling fn, when the queue is
+	// empty.
+	Pop(ctx context.Context, fn func(entry OutboxEntry) error) (bool, error)
+}
+
+// MemoryOutboxStore is an in-memory OutboxStore. Its entries are lost with
+// the process, so it only suits tests and best-effort delivery.
+type MemoryOutboxStore struct {
+	mu       sync.Mutex
+	entries  []OutboxEntry
+	inFlight bool
+}
+
+// NewMemoryOutboxStore creates an empty MemoryOutboxStore.
+func NewMemoryOutboxStore() *MemoryOutboxStore {
+	return &MemoryOutboxStore{}
+}
+
+// Put implements OutboxStore.
+func (s *MemoryOutboxStore) Put(ctx context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+// Pop implements OutboxStore. fn runs without the store locked, so Put is
+// never held up by a resend. While fn runs the entry stays at the head of the
+// queue and other Pops report the store as empty, so entries are never resent
+// twice or out of order.
+func (s *MemoryOutboxStore) Pop(ctx context.Context, fn func(entry OutboxEntry) error) (bool, error) {
+	s.mu.Lock()
+	if len(s.entries) == 0 || s.inFlight {
+		s.mu.Unlock()
+		return false, nil
+	}
+	entry := s.entries[0]
+	s.inFlight = true
+	s.mu.Unlock()
+
+	err := fn(entry)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight = false
+	if err != nil {
+		return true, err
+	}
+	// Put only appends, so the head is still entry.
+	s.entries = s.entries[1:]
+	return true, nil
+}
+
+// Len returns the number of queued entries.
+func (s *MemoryOutboxStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// outboxReplayKey marks the context of the sends of OutboxWorker. Their
+// messages were prepared when first sent, so they are sent as stored, and
+// they are never queued again.
+type outboxReplayKey struct{}
+
+func isOutboxReplay(ctx context.Context) bool {
+	return ctx.Value(outboxReplayKey{}) != nil
+}
+
+// queueInOutbox stores a message whose send failed with a retryable error in
+// the configured Outbox and returns the error SendMessage reports for it.
+func queueInOutbox(ctx context.Context, config *BotProviderConfig, message *models.GenericBotMessage, isDebug bool, sendErr error) error {
+	if config.Outbox == nil || !IsRetryable(sendErr) || isOutboxReplay(ctx) {
+		return sendErr
+	}
+
+	entry := OutboxEntry{Message: *message, IsDebug: isDebug, QueuedAt: time.Now(), Error: sendErr.Error()}
+	if err := config.Outbox.Put(context.WithoutCancel(ctx), entry); err != nil {
+		return errors.Join(sendErr, fmt.Errorf("failed to queue message in outbox: %w", err))
+	}
+	return fmt.Errorf("%w: %w", ErrQueuedInOutbox, sendErr)
+}
+
+type messageSender interface {
+	SendMessage(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (*models.GenericBotReply, error)
+}
+
+// OutboxWorker resends the messages queued in Store, oldest first. Messages
+// are sent one at a time, and one that keeps failing with a retryable error
+// holds back the rest, so the order they were sent in is kept.
+type OutboxWorker struct {
+	// Client sends the messages, usually the client whose config has Store as
+	// Outbox. The messages skip AutoMessageID and MessageInterceptor, which
+	// were applied when they were first sent, and resends that fail are never
+	// queued again.
+	Client messageSender
+	Store  OutboxStore
+	// Interval is the wait after a failed resend and between checks of an
+	// empty store. Defaults to 5s.
+	Interval time.Duration
+	// OnDelivered, when set, is called with each resent entry and its reply.
+	OnDelivered func(entry OutboxEntry, reply *models.GenericBotReply)
+	// OnDropped, when set, is called with entries removed from the store
+	// because their resend failed with an error that is not retryable.
+	OnDropped func(entry OutboxEntry, err error)
+}
+
+// Run resends queued messages until ctx is done and returns ctx's error.
+func (w *OutboxWorker) Run(ctx context.Context) error {
+	if w.Client == nil || w.Store == nil {
+		return fmt.Errorf("outbox worker requires a client and a store")
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultOutboxInterval
+	}
+
+	sendCtx := context.WithValue(ctx, outboxReplayKey{}, true)
+	for {
+		found, err := w.Store.Pop(ctx, func(entry OutboxEntry) error {
+			return w.resend(sendCtx, entry)
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if found && err == nil {
+			continue
+		}
+		if err != nil {
+			loggerFor(ctx, nil).WithError(err).Warn("failed to resend outbox message")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// resend sends entry and returns an error when it should stay queued.
+func (w *OutboxWorker) resend(ctx context.Context, entry OutboxEntry) error {
+	message := entry.Message
+	reply, err := w.Client.SendMessage(ctx, &message, entry.IsDebug)
+	switch {
+	case err == nil || errors.Is(err, ErrEmptyReply):
+		if w.OnDelivered != nil {
+			w.OnDelivered(entry, reply)
+		}
+		return nil
+	case ctx.Err() != nil || IsRetryable(err):
+		return err
+	}
+
+	if w.OnDropped != nil {
+		w.OnDropped(entry, err)
+	}
+	return nil
+}
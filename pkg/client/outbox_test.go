@@ -0,0 +1,130 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+func TestMemoryOutboxStorePutDuringPop(t *testing.T) {
+	store := client.NewMemoryOutboxStore()
+	ctx := context.Background()
+	if err := store.Put(ctx, client.OutboxEntry{Error: "first"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	resending := make(chan struct{})
+	release := make(chan struct{})
+	popped := make(chan error, 1)
+	go func() {
+		_, err := store.Pop(ctx, func(entry client.OutboxEntry) error {
+			close(resending)
+			<-release
+			return errors.New("still failing")
+		})
+		popped <- err
+	}()
+	<-resending
+
+	put := make(chan error, 1)
+	go func() { put <- store.Put(ctx, client.OutboxEntry{Error: "second"}) }()
+	select {
+	case err := <-put:
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Put not to wait for the resend")
+	}
+	if found, _ := store.Pop(ctx, func(client.OutboxEntry) error { return nil }); found {
+		t.Fatal("expected no entry to be handed out while one is resent")
+	}
+
+	close(release)
+	if err := <-popped; err == nil {
+		t.Fatal("expected the resend error")
+	}
+	var order []string
+	for {
+		found, err := store.Pop(ctx, func(entry client.OutboxEntry) error {
+			order = append(order, entry.Error)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Pop: %v", err)
+		}
+		if !found {
+			break
+		}
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Fatalf("expected the failed entry to stay at the head, got %v", order)
+	}
+}
+
+func TestOutboxReplaySkipsInterceptor(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"isSuccess":false,"error":"unavailable"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"isSuccess":true,"data":{"messages":[{"text":"ok"}]}}`))
+	}))
+	defer srv.Close()
+
+	store := client.NewMemoryOutboxStore()
+	var intercepted atomic.Int32
+	transport := &http.Transport{}
+	t.Cleanup(transport.CloseIdleConnections)
+	c := client.NewBotProviderClientWithConfig(&client.BotProviderConfig{
+		EdgeServerHost:    srv.URL,
+		Namespace:         "default",
+		BotProviderName:   "test-bot",
+		BotProviderApiKey: "test-key",
+		HTTPClient:        &http.Client{Transport: transport},
+		Outbox:            store,
+		MessageInterceptor: func(message *models.GenericBotMessage) error {
+			intercepted.Add(1)
+			message.Text += "!"
+			return nil
+		},
+	})
+
+	_, err := c.SendMessage(context.Background(), &models.GenericBotMessage{CustomChannelId: "ch", Text: "hi"}, false)
+	if !errors.Is(err, client.ErrQueuedInOutbox) {
+		t.Fatalf("expected the message to be queued, got %v", err)
+	}
+
+	fail.Store(false)
+	var texts []string
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	worker := &client.OutboxWorker{
+		Client:   c,
+		Store:    store,
+		Interval: 10 * time.Millisecond,
+		OnDelivered: func(entry client.OutboxEntry, reply *models.GenericBotReply) {
+			texts = append(texts, entry.Message.Text)
+			cancel()
+		},
+	}
+	_ = worker.Run(ctx)
+
+	if len(texts) != 1 || texts[0] != "hi!" {
+		t.Fatalf("expected the intercepted message to be resent once, got %v", texts)
+	}
+	if n := intercepted.Load(); n != 1 {
+		t.Fatalf("expected the interceptor to run once, ran %d times", n)
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// PersistFunc stores one stream event.
+type PersistFunc func(ctx context.Context, event *models.GenericBotSseEvent) error
+
+// PersistingStreamer is a BotProviderStreamer that also hands every event it
+// delivers to a PersistFunc on a separate goroutine.
+type PersistingStreamer interface {
+	BotProviderStreamer
+	// PersistErrors reports persistence failures as they happen. It is
+	// buffered and never blocks persistence: errors that do not fit are
+	// dropped from the channel, though WaitPersisted still returns them.
+	PersistErrors() <-chan error
+	// WaitPersisted blocks until every delivered event has been handed to the
+	// PersistFunc, which can only happen once the stream has ended or was
+	// closed, and returns all persistence errors joined.
+	WaitPersisted() error
+}
+
+// StreamWithPersistence streams the reply to message while persist stores
+// each event delivered by Next. Persistence runs on its own goroutine from an
+// unbounded queue, so a slow or failing store never holds up the consumer.
+func (a *botAgent) StreamWithPersistence(ctx context.Context, message *models.GenericBotMessage, persist PersistFunc) (PersistingStreamer, error) {
+	if persist == nil {
+		return nil, fmt.Errorf("persist cannot be nil")
+	}
+	stream, err := a.client.NewStreamer(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &persistingStream{
+		BotProviderStreamer: stream,
+		ctx:                 ctx,
+		persist:             persist,
+		errCh:               make(chan error, 64),
+		done:                make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	go s.run()
+	return s, nil
+}
+
+// persistingStream implements PersistingStreamer
+type persistingStream struct {
+	BotProviderStreamer
+	ctx     context.Context
+	persist PersistFunc
+	errCh   chan error
+	done    chan struct{}
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []*models.GenericBotSseEvent
+	ended  bool
+	errs   []error
+	closer sync.Once
+}
+
+func (s *persistingStream) Next() bool {
+	if !s.BotProviderStreamer.Next() {
+		s.end()
+		return false
+	}
+
+	s.mu.Lock()
+	s.queue = append(s.queue, s.BotProviderStreamer.Current())
+	s.mu.Unlock()
+	s.cond.Signal()
+	return true
+}
+
+func (s *persistingStream) Close() error {
+	err := s.BotProviderStreamer.Close()
+	s.end()
+	return err
+}
+
+func (s *persistingStream) PersistErrors() <-chan error {
+	return s.errCh
+}
+
+func (s *persistingStream) WaitPersisted() error {
+	<-s.done
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.errs...)
+}
+
+// end stops accepting events; queued ones are still persisted.
+func (s *persistingStream) end() {
+	s.closer.Do(func() {
+		s.mu.Lock()
+		s.ended = true
+		s.mu.Unlock()
+		s.cond.Signal()
+	})
+}
+
+func (s *persistingStream) run() {
+	defer close(s.done)
+	defer close(s.errCh)
+
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.ended {
+			s.cond.Wait()
+		}
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		event := s.queue[0]
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		if err := s.persist(s.ctx, event); err != nil {
+			err = fmt.Errorf("failed to persist event %s: %w", event.EventId, err)
+			s.mu.Lock()
+			s.errs = append(s.errs, err)
+			s.mu.Unlock()
+			select {
+			case s.errCh <- err:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrUnreachable is wrapped by the error Ping returns when the
+	// EdgeServer could not be reached: DNS, connection and TLS failures and
+	// request timeouts.
+	ErrUnreachable = errors.New("edge server unreachable")
+	// ErrUnauthorized is wrapped by the error Ping returns when the
+	// EdgeServer rejects the API key.
+	ErrUnauthorized = errors.New("api key rejected")
+	// ErrBotProviderNotFound is wrapped by the error Ping returns when the
+	// EdgeServer does not know the namespace or bot provider.
+	ErrBotProviderNotFound = errors.New("bot provider not found")
+)
+
+// Ping checks that the primary endpoint is reachable, knows the configured
+// bot provider and accepts the API key, with the same read-only request as
+// VerifyAPIKey, so nothing reaches the bot. It returns nil for a success
+// status. Failures wrap ErrUnreachable, ErrUnauthorized or
+// ErrBotProviderNotFound, along with the underlying error, so they can be
+// told apart with errors.Is; the HTTP failures also wrap an *APIError. Any
+// other status, which says nothing about the key, is returned as a plain
+// *APIError and a done ctx as its error.
+func (c *BotProviderClient) Ping(ctx context.Context) (err error) {
+	ctx, hook := startRequest(ctx, c.config, HookEndpointPing)
+	defer func() { hook.done(err) }()
+
+	resp, respBytes, err := c.probe(ctx, hook, "failed to ping")
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+
+	switch {
+	case isAuthStatus(resp.StatusCode):
+		return fmt.Errorf("%w: %w", ErrUnauthorized, newAPIError(c.config, "ping", resp, respBytes))
+	case probeAccepted(resp.StatusCode):
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %w", ErrBotProviderNotFound, newAPIError(c.config, "ping", resp, respBytes))
+	default:
+		return newAPIError(c.config, "ping", resp, respBytes)
+	}
+}
@@ -0,0 +1,59 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+)
+
+func TestPingStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		wantErr   bool
+		wantIsErr error
+	}{
+		{http.StatusOK, false, nil},
+		{http.StatusMethodNotAllowed, true, nil},
+		{http.StatusUnauthorized, true, client.ErrUnauthorized},
+		{http.StatusNotFound, true, client.ErrBotProviderNotFound},
+		{http.StatusBadRequest, true, nil},
+		{http.StatusBadGateway, true, nil},
+	}
+	for _, tc := range tests {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			var endpoints []string
+			transport := &http.Transport{}
+			t.Cleanup(transport.CloseIdleConnections)
+			c := client.NewBotProviderClientWithConfig(&client.BotProviderConfig{
+				EdgeServerHost:    srv.URL,
+				Namespace:         "default",
+				BotProviderName:   "test-bot",
+				BotProviderApiKey: "test-key",
+				HTTPClient:        &http.Client{Transport: transport},
+				OnRequestStart: func(endpoint string, ctx context.Context) {
+					endpoints = append(endpoints, endpoint)
+				},
+			})
+
+			err := c.Ping(context.Background())
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error=%v, got %v", tc.wantErr, err)
+			}
+			if tc.wantIsErr != nil && !errors.Is(err, tc.wantIsErr) {
+				t.Fatalf("expected error wrapping %v, got %v", tc.wantIsErr, err)
+			}
+			if len(endpoints) != 1 || endpoints[0] != client.HookEndpointPing {
+				t.Fatalf("expected the request to be reported as %q, got %v", client.HookEndpointPing, endpoints)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// priorityLimiter is a token bucket whose waiters are served highest
+// priority first and, within a priority, in arrival order.
+type priorityLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+	waiters  waiterHeap
+	seq      uint64
+	timer    *time.Timer
+}
+
+type limiterWaiter struct {
+	priority int
+	seq      uint64
+	ready    chan struct{}
+	index    int
+}
+
+func newPriorityLimiter(ratePerSecond float64, burst int) *priorityLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &priorityLimiter{
+		interval: time.Duration(float64(time.Second) / ratePerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a token is granted to the caller or ctx is done.
+func (l *priorityLimiter) wait(ctx context.Context, priority int) error {
+	l.mu.Lock()
+	l.refill(time.Now())
+	if len(l.waiters) == 0 && l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+
+	l.seq++
+	w := &limiterWaiter{priority: priority, seq: l.seq, ready: make(chan struct{})}
+	heap.Push(&l.waiters, w)
+	l.schedule()
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-w.ready:
+			// Granted as ctx ended; hand the token to the next waiter.
+			l.tokens++
+			l.dispatch()
+		default:
+			heap.Remove(&l.waiters, w.index)
+		}
+		return ctx.Err()
+	}
+}
+
+func (l *priorityLimiter) refill(now time.Time) {
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// dispatch grants available tokens to waiters in priority order. The caller
+// holds mu.
+func (l *priorityLimiter) dispatch() {
+	l.refill(time.Now())
+	for len(l.waiters) > 0 && l.tokens >= 1 {
+		l.tokens--
+		w := heap.Pop(&l.waiters).(*limiterWaiter)
+		close(w.ready)
+	}
+	l.schedule()
+}
+
+// schedule arranges for dispatch to run when the next token is due, if
+// anyone is waiting. The caller holds mu.
+func (l *priorityLimiter) schedule() {
+	if len(l.waiters) == 0 || l.timer != nil {
+		return
+	}
+	due := time.Duration((1 - l.tokens) * float64(l.interval))
+	l.timer = time.AfterFunc(due, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.timer = nil
+		l.dispatch()
+	})
+}
+
+// waiterHeap orders waiters by descending priority, then ascending seq.
+type waiterHeap []*limiterWaiter
+
+func (h waiterHeap) Len() int { return len(h) }
+
+func (h waiterHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waiterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waiterHeap) Push(x any) {
+	w := x.(*limiterWaiter)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waiterHeap) Pop() any {
+	old := *h
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return w
+}
@@ -0,0 +1,11 @@
+package client
+
+import "go.asgard-ai.com/asgard-sdk-go/pkg/models"
+
+// reportProcessLog passes the line of a process log event to OnProcessLog.
+func reportProcessLog(config *BotProviderConfig, event *models.GenericBotSseEvent) {
+	if config.OnProcessLog == nil || event.Fact.ProcessLog == nil {
+		return
+	}
+	config.OnProcessLog(event.Fact.ProcessLog.ProcessId, event.Fact.ProcessLog.Line)
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tmaxmax/go-sse"
+)
+
+// dumpRawEvent writes the type and data of event to w in the SSE wire
+// format, as the stream received them and before the data is decoded.
+func dumpRawEvent(w io.Writer, logger log.FieldLogger, event sse.Event) {
+	if w == nil {
+		return
+	}
+
+	var b strings.Builder
+	if event.Type != "" {
+		fmt.Fprintf(&b, "event: %s\n", event.Type)
+	}
+	for _, line := range strings.Split(event.Data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		logger.WithError(err).Debug("[EdgeServer] Failed to dump raw SSE event")
+	}
+}
@@ -0,0 +1,276 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tmaxmax/go-sse"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+const (
+	defaultAutoReconnectDelay = time.Second
+	maxAutoReconnectDelay     = 30 * time.Second
+)
+
+// reconnectingStream is a BotProviderStreamer that connects its stream again
+// when it fails with a transport error. See AutoReconnect.
+type reconnectingStream struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	config       *BotProviderConfig
+	logger       log.FieldLogger
+	connect      func(ctx context.Context) (BotProviderStreamer, error)
+	stream       BotProviderStreamer
+	connectErr   error
+	currentEvent *models.GenericBotSseEvent
+	err          error
+	closed       bool
+	attempts     int
+	lastEventID  string
+	seenEventIDs map[string]struct{}
+	requestID    atomic.Value
+	mu           sync.Mutex
+	// streamMu guards stream and closed, so Close can stop a Next in
+	// progress.
+	streamMu sync.Mutex
+}
+
+// newReconnectingStream connects a stream through connect. An initial
+// failure that is worth retrying is retried by Next.
+func newReconnectingStream(ctx context.Context, config *BotProviderConfig, connect func(ctx context.Context) (BotProviderStreamer, error)) (BotProviderStreamer, error) {
+	s := &reconnectingStream{
+		config:  config,
+		logger:  loggerFor(ctx, config),
+		connect: connect,
+	}
+	if config.SSEResume {
+		s.seenEventIDs = make(map[string]struct{})
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	stream, err := connect(s.ctx)
+	if err != nil {
+		if !retryableStreamError(s.ctx, err) {
+			s.cancel()
+			return nil, err
+		}
+		s.connectErr = err
+	}
+	s.stream = stream
+	return s, nil
+}
+
+// Next advances to the next event, connecting again when the stream fails
+// with a transport error. Returns false if there are no more events, an
+// error occurred or the attempts are used up.
+func (s *reconnectingStream) Next() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return false
+	}
+
+	for {
+		s.streamMu.Lock()
+		stream, closed := s.stream, s.closed
+		s.streamMu.Unlock()
+		if closed {
+			return false
+		}
+
+		err := s.connectErr
+		if stream != nil {
+			if stream.Next() {
+				if s.deliver(stream.Current()) {
+					return true
+				}
+				continue
+			}
+			err = stream.Err()
+			_ = stream.Close()
+		}
+
+		if s.isClosed() {
+			return false
+		}
+		if !retryableStreamError(s.ctx, err) {
+			s.err = err
+			return false
+		}
+		if !s.reconnect(err) {
+			return false
+		}
+	}
+}
+
+// deliver makes event the current event, or reports false for events a
+// resumed connection replayed.
+func (s *reconnectingStream) deliver(event *models.GenericBotSseEvent) bool {
+	if s.seenEventIDs != nil && event.EventId != "" {
+		if _, seen := s.seenEventIDs[event.EventId]; seen {
+			s.logger.WithField("event_id", event.EventId).Debug("[EdgeServer] Skipping replayed stream event")
+			return false
+		}
+		s.seenEventIDs[event.EventId] = struct{}{}
+	}
+	if event.EventId != "" {
+		s.lastEventID = event.EventId
+	}
+	if event.RequestId != "" {
+		s.requestID.Store(event.RequestId)
+	}
+
+	s.attempts = 0
+	s.currentEvent = event
+	return true
+}
+
+// reconnect connects a new stream after the current one failed with lastErr,
+// waiting between attempts. It returns false, with s.err set, once the
+// attempts are used up or an attempt fails with an error not worth retrying.
+func (s *reconnectingStream) reconnect(lastErr error) bool {
+	for {
+		if s.config.AutoReconnect > 0 && s.attempts >= s.config.AutoReconnect {
+			s.err = fmt.Errorf("stream failed after %d reconnect attempts: %w", s.attempts, lastErr)
+			return false
+		}
+		s.attempts++
+		s.logger.WithError(lastErr).WithField("attempt", s.attempts).Warn("[EdgeServer] Stream failed, reconnecting")
+		if s.config.OnReconnect != nil {
+			s.config.OnReconnect(s.attempts, lastErr)
+		}
+
+		timer := time.NewTimer(s.delay())
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			timer.Stop()
+			if !s.isClosed() {
+				s.err = s.ctx.Err()
+			}
+			return false
+		}
+
+		stream, err := s.connect(s.resumeContext())
+		if err != nil {
+			if s.isClosed() {
+				return false
+			}
+			if !retryableStreamError(s.ctx, err) {
+				s.err = err
+				return false
+			}
+			lastErr = err
+			continue
+		}
+
+		s.streamMu.Lock()
+		if s.closed {
+			s.streamMu.Unlock()
+			_ = stream.Close()
+			return false
+		}
+		s.stream, s.connectErr = stream, nil
+		s.streamMu.Unlock()
+		return true
+	}
+}
+
+// delay returns the wait before the current attempt.
+func (s *reconnectingStream) delay() time.Duration {
+	d := s.config.AutoReconnectDelay
+	if d <= 0 {
+		d = defaultAutoReconnectDelay
+	}
+	for i := 1; i < s.attempts && d < maxAutoReconnectDelay; i++ {
+		d *= 2
+	}
+	return min(d, maxAutoReconnectDelay)
+}
+
+// resumeContext returns the context to connect with, carrying Last-Event-ID
+// when the stream resumes from the last event.
+func (s *reconnectingStream) resumeContext() context.Context {
+	if !s.config.SSEResume || s.lastEventID == "" {
+		return s.ctx
+	}
+	opts := requestOptionsFromContext(s.ctx)
+	headers := make(map[string]string, len(opts.Headers)+1)
+	for k, v := range opts.Headers {
+		headers[k] = v
+	}
+	headers["Last-Event-ID"] = s.lastEventID
+	opts.Headers = headers
+	return WithRequestOptions(s.ctx, opts)
+}
+
+func (s *reconnectingStream) isClosed() bool {
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+	return s.closed
+}
+
+// retryableStreamError reports whether a stream that ended with err is worth
+// connecting again: transport failures and retryable statuses are; run
+// errors, auth failures, malformed events and the context ending are not.
+func retryableStreamError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var detail *models.ErrorDetail
+	if errors.As(err, &detail) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return IsRetryable(apiErr)
+	}
+	var connErr *sse.ConnectionError
+	return errors.As(err, &connErr) || IsRetryable(err)
+}
+
+// RequestID returns the RequestId of the events received so far.
+func (s *reconnectingStream) RequestID() string {
+	id, _ := s.requestID.Load().(string)
+	return id
+}
+
+// Current returns the current event. Should only be called after Next() returns true.
+func (s *reconnectingStream) Current() *models.GenericBotSseEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.currentEvent
+}
+
+// Err returns any error that occurred during streaming
+func (s *reconnectingStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close closes the stream and stops reconnecting. It may be called while
+// another goroutine is blocked in Next, which then returns false.
+func (s *reconnectingStream) Close() error {
+	s.streamMu.Lock()
+	if s.closed {
+		s.streamMu.Unlock()
+		return nil
+	}
+	s.closed = true
+	stream := s.stream
+	s.streamMu.Unlock()
+
+	s.cancel()
+	if stream != nil {
+		return stream.Close()
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// referenceTracker reports the references of a stream to OnReferences as
+// they are discovered, once per URI.
+type referenceTracker struct {
+	onReferences func([]models.MessageTemplateReference)
+	mu           sync.Mutex
+	seen         map[string]struct{}
+}
+
+func newReferenceTracker(config *BotProviderConfig) *referenceTracker {
+	if config.OnReferences == nil {
+		return nil
+	}
+	return &referenceTracker{onReferences: config.OnReferences, seen: make(map[string]struct{})}
+}
+
+// observe passes the references of event not seen before to OnReferences.
+func (t *referenceTracker) observe(event *models.GenericBotSseEvent) {
+	if t == nil {
+		return
+	}
+	refs := event.References()
+	if len(refs) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	var fresh []models.MessageTemplateReference
+	for _, ref := range refs {
+		if _, ok := t.seen[ref.Uri]; ok {
+			continue
+		}
+		t.seen[ref.Uri] = struct{}{}
+		fresh = append(fresh, ref)
+	}
+	t.mu.Unlock()
+
+	if len(fresh) > 0 {
+		t.onReferences(fresh)
+	}
+}
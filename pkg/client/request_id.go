@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// RequestIDHeader is the header carrying the request ID set with
+// WithRequestID.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying a client-generated request ID.
+// Every request made with it, including stream connections and their
+// reconnects, sends the ID as the X-Request-ID header so client-side traces
+// can be correlated with the server.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// RequestIDFromEvent returns the server's request ID of the run event
+// belongs to, or "" for a nil event.
+func RequestIDFromEvent(event *models.GenericBotSseEvent) string {
+	if event == nil {
+		return ""
+	}
+	return event.RequestId
+}
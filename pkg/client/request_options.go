@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const defaultJSONContentType = "application/json"
+
+// TimezoneHeader is the header carrying the Timezone of a request.
+const TimezoneHeader = "X-Timezone"
+
+// RequestOptions adjusts a single call. Attach them to the call's context with
+// WithRequestOptions; calls without options use the client defaults.
+type RequestOptions struct {
+	// ContentType overrides the Content-Type of TriggerJSON requests, e.g.
+	// "application/json; charset=utf-8" or a vendor JSON type. TriggerForm
+	// always sends multipart/form-data.
+	ContentType string
+
+	// Headers are added to the requests of the call, stream connections
+	// included, on top of the config-level Headers, replacing them on
+	// conflict. They cannot replace X-API-KEY unless AllowAPIKeyOverride is
+	// set.
+	Headers             map[string]string
+	AllowAPIKeyOverride bool
+
+	// Priority orders SendMessage calls waiting on SendRateLimit: higher
+	// priorities are dispatched first, calls of equal priority in the order
+	// they started waiting. Without a rate limit it has no effect.
+	Priority int
+
+	// AcceptLanguage is sent as the Accept-Language header, e.g. "zh-TW", so
+	// the bot can localize its reply. It overrides the config-level
+	// AcceptLanguage.
+	AcceptLanguage string
+	// Timezone is sent as the X-Timezone header, an IANA name such as
+	// "Asia/Taipei", so the bot formats dates and times for the user. It
+	// overrides the config-level Timezone.
+	Timezone string
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a copy of ctx carrying opts for calls made with it.
+func WithRequestOptions(ctx context.Context, opts RequestOptions) context.Context {
+	return context.WithValue(ctx, requestOptionsKey{}, opts)
+}
+
+func requestOptionsFromContext(ctx context.Context) RequestOptions {
+	opts, _ := ctx.Value(requestOptionsKey{}).(RequestOptions)
+	return opts
+}
+
+// jsonContentType returns the Content-Type to send for a JSON trigger body.
+func (o RequestOptions) jsonContentType() (string, error) {
+	if o.ContentType == "" {
+		return defaultJSONContentType, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(o.ContentType)
+	if err != nil {
+		return "", fmt.Errorf("invalid content type %q: %w", o.ContentType, err)
+	}
+	if strings.Count(mediaType, "/") != 1 || strings.HasPrefix(mediaType, "/") || strings.HasSuffix(mediaType, "/") {
+		return "", fmt.Errorf("invalid content type %q: expected type/subtype", o.ContentType)
+	}
+	return o.ContentType, nil
+}
+
+// applyHeaders sets the config-level headers and then the per-call headers
+// from opts on req, each followed by its locale headers. Headers across both
+// sets never replace the X-API-KEY set by the client unless opts explicitly
+// allows it.
+func applyHeaders(req *http.Request, config *BotProviderConfig, opts RequestOptions) {
+	for k, v := range config.Headers {
+		if http.CanonicalHeaderKey(k) == "X-Api-Key" {
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	setLocaleHeaders(req, config.AcceptLanguage, config.Timezone)
+	for k, v := range opts.Headers {
+		if http.CanonicalHeaderKey(k) == "X-Api-Key" && !opts.AllowAPIKeyOverride {
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	setLocaleHeaders(req, opts.AcceptLanguage, opts.Timezone)
+}
+
+func setLocaleHeaders(req *http.Request, acceptLanguage, timezone string) {
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	if timezone != "" {
+		req.Header.Set(TimezoneHeader, timezone)
+	}
+}
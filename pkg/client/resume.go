@@ -0,0 +1,46 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+)
+
+// lastEventIDTransport sets Last-Event-ID on SSE reconnects from the EventId
+// of the last event received. go-sse only tracks ids sent as SSE "id:" fields,
+// while EdgeServer carries them in the event payload.
+type lastEventIDTransport struct {
+	base http.RoundTripper
+	mu   sync.Mutex
+	id   string
+}
+
+func (t *lastEventIDTransport) setLastEventID(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.id = id
+}
+
+func (t *lastEventIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	id := t.id
+	t.mu.Unlock()
+
+	if id == "" || req.Header.Get("Last-Event-ID") != "" {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Last-Event-ID", id)
+	return t.base.RoundTrip(req)
+}
+
+// withLastEventID returns a shallow copy of client whose transport resumes
+// streams through t.
+func withLastEventID(client *http.Client, t *lastEventIDTransport) *http.Client {
+	t.base = client.Transport
+	if t.base == nil {
+		t.base = http.DefaultTransport
+	}
+	clone := *client
+	clone.Transport = t
+	return &clone
+}
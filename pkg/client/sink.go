@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// EventSink receives the events of a stream driven by RunWithSink.
+type EventSink interface {
+	Handle(event *models.GenericBotSseEvent) error
+}
+
+// EventSinkFunc adapts a function to EventSink.
+type EventSinkFunc func(event *models.GenericBotSseEvent) error
+
+// Handle calls f(event).
+func (f EventSinkFunc) Handle(event *models.GenericBotSseEvent) error {
+	return f(event)
+}
+
+// RunWithSink streams the reply to message and pushes each event to sink. It
+// returns nil once RunDone has been handled, the sink's error if Handle
+// fails, or the stream error, which for a run error is an *ErrorDetail.
+func (a *botAgent) RunWithSink(ctx context.Context, message *models.GenericBotMessage, sink EventSink) error {
+	stream, err := a.client.NewStreamer(ctx, message)
+	if err != nil {
+		return err
+	}
+	return drainToSink(stream, sink)
+}
+
+// drainToSink pushes every event of stream to sink and closes the stream.
+func drainToSink(stream BotProviderStreamer, sink EventSink) error {
+	defer stream.Close()
+
+	for stream.Next() {
+		event := stream.Current()
+		if err := sink.Handle(event); err != nil {
+			return err
+		}
+		if event.EventType == models.SseEventTypeRunDone {
+			return nil
+		}
+	}
+	return stream.Err()
+}
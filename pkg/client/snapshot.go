@@ -0,0 +1,51 @@
+package client
+
+import (
+	"sync"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// SnapshotStreamer is a BotProviderStreamer that also keeps its latest
+// events. SSE streams implement it; Snapshot returns nil unless the config
+// sets SnapshotSize.
+type SnapshotStreamer interface {
+	BotProviderStreamer
+	// Snapshot returns the most recently received events, oldest first. It
+	// reflects what the connection received, which may be ahead of Next.
+	Snapshot() []*models.GenericBotSseEvent
+}
+
+// eventRing is a fixed-size buffer of the latest events that overwrites its
+// oldest entry when full.
+type eventRing struct {
+	mu     sync.Mutex
+	events []*models.GenericBotSseEvent
+	next   int
+	full   bool
+}
+
+func newEventRing(size int) *eventRing {
+	return &eventRing{events: make([]*models.GenericBotSseEvent, size)}
+}
+
+func (r *eventRing) add(event *models.GenericBotSseEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[r.next] = event
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *eventRing) snapshot() []*models.GenericBotSseEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]*models.GenericBotSseEvent(nil), r.events[:r.next]...)
+	}
+	out := make([]*models.GenericBotSseEvent, 0, len(r.events))
+	out = append(out, r.events[r.next:]...)
+	return append(out, r.events[:r.next]...)
+}
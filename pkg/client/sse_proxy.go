@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// WriteSSE forwards every event of stream to w as Server-Sent Events, using
+// the event type as the SSE event name, the EventId as the SSE id and the
+// JSON-encoded event as data. Each event is flushed as soon as it is written.
+//
+// A run error ending the stream is forwarded as a final asgard.run.error
+// event before WriteSSE returns it. A failed write means the browser went
+// away; the upstream stream is closed and the write error returned. The
+// stream is always closed on return, and should be created with the
+// incoming request's context so a disconnect also cancels it while WriteSSE
+// waits for the next event.
+func WriteSSE(w http.ResponseWriter, stream BotProviderStreamer) error {
+	defer stream.Close()
+
+	rc := http.NewResponseController(w)
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return fmt.Errorf("failed to flush SSE response: %w", err)
+	}
+
+	var last *models.GenericBotSseEvent
+	for stream.Next() {
+		last = stream.Current()
+		if err := writeSSEEvent(w, last); err != nil {
+			return fmt.Errorf("failed to write SSE event: %w", err)
+		}
+		if err := rc.Flush(); err != nil {
+			return fmt.Errorf("failed to flush SSE event: %w", err)
+		}
+	}
+
+	err := stream.Err()
+	if err == nil {
+		return nil
+	}
+
+	var detail *models.ErrorDetail
+	if errors.As(err, &detail) {
+		event := &models.GenericBotSseEvent{EventType: models.SseEventTypeRunError}
+		if last != nil {
+			event.RequestId = last.RequestId
+			event.Namespace = last.Namespace
+			event.BotProviderName = last.BotProviderName
+			event.CustomChannelId = last.CustomChannelId
+		}
+		event.Fact.RunError = &models.GenericBotSseEventFactRunError{Error: *detail}
+		if writeErr := writeSSEEvent(w, event); writeErr == nil {
+			_ = rc.Flush()
+		}
+	}
+	return err
+}
+
+func writeSSEEvent(w io.Writer, event *models.GenericBotSseEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if event.EventId != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", event.EventId); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.EventType, data)
+	return err
+}
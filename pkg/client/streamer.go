@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/tmaxmax/go-sse"
@@ -23,17 +26,52 @@ type BotProviderStreamer interface {
 	Close() error
 }
 
+// GracefulStreamer is a BotProviderStreamer whose shutdown can be awaited.
+// SSE streams implement it.
+type GracefulStreamer interface {
+	BotProviderStreamer
+	// CloseWithTimeout closes the stream like Close and waits up to d for its
+	// connection goroutine to exit. It returns an error wrapping
+	// context.DeadlineExceeded when the goroutine is still running after d.
+	CloseWithTimeout(d time.Duration) error
+}
+
+// RequestIDStreamer is a BotProviderStreamer that knows the RequestId of its
+// run, e.g. to pass to CancelRun. SSE and long-poll streams implement it.
+type RequestIDStreamer interface {
+	BotProviderStreamer
+	// RequestID returns the RequestId of the stream's run, or "" until the
+	// first event has been received. It is safe to call while another
+	// goroutine is blocked in Next.
+	RequestID() string
+}
+
 // botProviderStream implements BotProviderStreamer
 type botProviderStream struct {
 	ctx          context.Context
+	connCtx      context.Context
+	cancel       context.CancelFunc
 	config       *BotProviderConfig
-	message      *models.GenericBotMessage
+	logger       log.FieldLogger
+	request      sseRequest
 	sseClient    *sse.Client
 	connection   *sse.Connection
 	eventChan    chan models.GenericBotSseEventWrapper
+	stopped      chan struct{}
 	currentEvent *models.GenericBotSseEvent
 	err          error
 	closed       bool
+	finished     atomic.Bool
+	reconnects   int
+	hook         *requestHook
+	ring         *eventRing
+	references   *referenceTracker
+	counter      *eventCounter
+	order        *eventOrderChecker
+	doneWhen     func(event *models.GenericBotSseEvent) bool
+	resume       *lastEventIDTransport
+	seenEventIDs map[string]struct{}
+	requestID    atomic.Value
 	mu           sync.Mutex
 }
 
@@ -45,114 +83,332 @@ func NewStreaming(ctx context.Context, config *BotProviderConfig, message *model
 	if message == nil {
 		return nil, fmt.Errorf("message cannot be nil")
 	}
+	if err := prepareMessage(config, message); err != nil {
+		return nil, err
+	}
 
-	sseClient := &sse.Client{
-		Backoff: sse.Backoff{
-			MaxRetries: -1,
-		},
+	if config.AutoReconnect != 0 {
+		return newReconnectingStream(ctx, config, func(ctx context.Context) (BotProviderStreamer, error) {
+			return connectStream(ctx, config, message)
+		})
 	}
+	return connectStream(ctx, config, message)
+}
 
-	if config.HTTPClient != nil {
-		sseClient.HTTPClient = config.HTTPClient
+// connectStream connects a stream of the reply to a prepared message over
+// the configured transport.
+func connectStream(ctx context.Context, config *BotProviderConfig, message *models.GenericBotMessage) (BotProviderStreamer, error) {
+	switch config.Transport {
+	case "", TransportSSE:
+	case TransportSSESync:
+		return newSyncSSEStream(ctx, config, sseRequest{
+			path:     ssePath(config),
+			endpoint: HookEndpointSSE,
+			payload:  outgoingMessage(config, message),
+		})
+	case TransportLongPoll:
+		return newLongPollStream(ctx, config, message)
+	default:
+		return nil, fmt.Errorf("unknown stream transport %q", config.Transport)
 	}
 
-	stream := &botProviderStream{
-		ctx:       ctx,
-		config:    config,
-		message:   message,
-		eventChan: make(chan models.GenericBotSseEventWrapper, 100),
-		sseClient: sseClient,
+	return newSSEStream(ctx, config, sseRequest{
+		path:     ssePath(config),
+		endpoint: HookEndpointSSE,
+		payload:  outgoingMessage(config, message),
+	})
+}
+
+func streamBufferSize(config *BotProviderConfig) int {
+	if config.StreamBufferSize > 0 {
+		return config.StreamBufferSize
 	}
+	return defaultStreamBufferSize
+}
 
-	if err := stream.connect(); err != nil {
-		return nil, fmt.Errorf("failed to establish SSE connection: %w", err)
+func streamMaxTokenSize(config *BotProviderConfig) int {
+	if config.StreamMaxTokenSize > 0 {
+		return config.StreamMaxTokenSize
 	}
+	return defaultStreamMaxTokenSize
+}
 
-	return stream, nil
+// ssePath returns the path of the message SSE endpoint, relative to the bot
+// provider.
+func ssePath(config *BotProviderConfig) string {
+	if path := strings.TrimPrefix(config.SSEPath, "/"); path != "" {
+		return path
+	}
+	return defaultSSEPath
 }
 
-// connect establishes the SSE connection
-func (s *botProviderStream) connect() error {
-	// Marshal the message
-	messageBytes, err := json.Marshal(s.message)
+// sseRequest describes the endpoint an SSE stream connects to.
+type sseRequest struct {
+	path        string
+	endpoint    string
+	payload     interface{}
+	contentType string
+}
+
+// streamHTTPClient returns the HTTP client SSE connections are made with.
+func streamHTTPClient(config *BotProviderConfig) *http.Client {
+	switch {
+	case config.SSEHTTPClient != nil:
+		return config.SSEHTTPClient
+	case config.HTTPClient != nil:
+		return withoutTimeout(config.HTTPClient)
+	default:
+		return newSSEHTTPClient(config)
+	}
+}
+
+// newSSERequest builds the POST of an SSE connection for r on ctx. The
+// per-call options are taken from callCtx.
+func newSSERequest(ctx, callCtx context.Context, config *BotProviderConfig, logger log.FieldLogger, r sseRequest) (*http.Request, error) {
+	// Marshal the payload
+	messageBytes, err := json.Marshal(r.payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal bot message: %w", err)
+		return nil, fmt.Errorf("failed to marshal SSE payload: %w", err)
 	}
 
 	// Create HTTP request
-	url := fmt.Sprintf("%s/ns/%s/bot-provider/%s/message/sse",
-		s.config.EdgeServerHost, s.config.Namespace, s.config.BotProviderName)
+	url := fmt.Sprintf("%s/ns/%s/bot-provider/%s/%s",
+		config.EdgeServerHost, config.Namespace, config.BotProviderName, r.path)
 
 	// Log request details for debugging
-	log.WithFields(log.Fields{
+	logger.WithFields(log.Fields{
 		"url":  url,
 		"body": string(messageBytes),
 	}).Debug("[EdgeServer] Sending SSE request")
 
-	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, url, bytes.NewBuffer(messageBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(messageBytes))
 	if err != nil {
-		return fmt.Errorf("failed to create SSE request: %w", err)
+		return nil, fmt.Errorf("failed to create SSE request: %w", err)
+	}
+	applyRequestOverrides(req, config)
+
+	req.Header.Set("Content-Type", r.contentType)
+	req.Header.Set("x-api-key", config.BotProviderApiKey)
+	applyHeaders(req, config, requestOptionsFromContext(callCtx))
+	return req, nil
+}
+
+// newSSEStream connects an SSE stream that POSTs r.payload to r.path.
+func newSSEStream(ctx context.Context, config *BotProviderConfig, r sseRequest) (BotProviderStreamer, error) {
+	if r.contentType == "" {
+		r.contentType = defaultJSONContentType
+	}
+
+	sseClient := &sse.Client{
+		Backoff: sse.Backoff{
+			MaxRetries: sseMaxRetries(config.SSEMaxRetries),
+		},
+	}
+
+	sseClient.HTTPClient = streamHTTPClient(config)
+
+	stream := &botProviderStream{
+		ctx:        ctx,
+		config:     config,
+		logger:     loggerFor(ctx, config),
+		request:    r,
+		eventChan:  make(chan models.GenericBotSseEventWrapper, streamBufferSize(config)),
+		stopped:    make(chan struct{}),
+		sseClient:  sseClient,
+		references: newReferenceTracker(config),
+		counter:    newEventCounter(config),
+	}
+	stream.order = newEventOrderChecker(config, stream.logger)
+	stream.doneWhen = newDonePredicate(config)
+	// The connection is made with the context carrying the stream's span.
+	var spanCtx context.Context
+	spanCtx, stream.hook = startRequest(ctx, config, r.endpoint)
+	stream.connCtx, stream.cancel = context.WithCancel(spanCtx)
+	if config.SSEResume {
+		stream.resume = &lastEventIDTransport{}
+		stream.seenEventIDs = make(map[string]struct{})
+		sseClient.HTTPClient = withLastEventID(sseClient.HTTPClient, stream.resume)
+	}
+	if config.SnapshotSize > 0 {
+		stream.ring = newEventRing(config.SnapshotSize)
+	}
+
+	sseClient.OnRetry = func(err error, _ time.Duration) {
+		// go-sse also retries after the server closes a finished run; that
+		// is not a reconnect, the connection is torn down right after.
+		if stream.finished.Load() {
+			return
+		}
+		stream.reconnects++
+		stream.logger.WithError(err).WithField("attempt", stream.reconnects).Warn("[EdgeServer] SSE connection lost, reconnecting")
+		if config.OnReconnect != nil {
+			config.OnReconnect(stream.reconnects, err)
+		}
+	}
+
+	sseClient.ResponseValidator = func(resp *http.Response) error {
+		stream.hook.status = resp.StatusCode
+		// Failed statuses surface as *APIError, like the other calls, so
+		// callers can classify them.
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return newAPIError(config, "stream", resp, body)
+		}
+		return sse.DefaultValidator(resp)
+	}
+
+	if err := stream.connect(); err != nil {
+		stream.cancel()
+		err = fmt.Errorf("failed to establish SSE connection: %w", err)
+		stream.hook.done(err)
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", s.config.BotProviderApiKey)
-	for k, v := range s.config.Headers {
-		req.Header.Set(k, v)
+	return stream, nil
+}
+
+// connect establishes the SSE connection
+func (s *botProviderStream) connect() error {
+	req, err := newSSERequest(s.connCtx, s.ctx, s.config, s.logger, s.request)
+	if err != nil {
+		return err
 	}
 
-	// Create SSE connection
-	buf := make([]byte, 0, 1024*1024) // Buffer starting at 1MB
-	maxToken := 1024 * 1024 * 10      // Buffer max token size at 10MB
+	// Create SSE connection. The read buffer grows up to the max token size,
+	// so large events do not fail with a token too long error.
+	maxToken := streamMaxTokenSize(s.config)
+	buf := make([]byte, 0, min(defaultStreamReadBuffer, maxToken))
 	s.connection = s.sseClient.
 		NewConnection(req)
-	s.connection.Buffer(buf, maxToken) // Set buffer size to 1MB and max token to 10MB to prevent token too long error
+	s.connection.Buffer(buf, maxToken)
 
 	// Subscribe to events
 	s.connection.SubscribeToAll(func(event sse.Event) {
 		// Log raw SSE event for debugging
-		log.WithFields(log.Fields{
+		s.logger.WithFields(log.Fields{
 			"event_type": event.Type,
 			"event_data": event.Data,
 		}).Debug("[EdgeServer] Received SSE event")
+		dumpRawEvent(s.config.DumpRawTo, s.logger, event)
 
 		var edgeEvent models.GenericBotSseEvent
 		if err := json.Unmarshal([]byte(event.Data), &edgeEvent); err != nil {
-			log.WithError(err).WithField("raw_data", event.Data).Error("[EdgeServer] Failed to unmarshal SSE event")
-			s.eventChan <- models.GenericBotSseEventWrapper{
-				Event:           nil,
-				ConnectionError: fmt.Errorf("failed to unmarshal event: %w", err),
+			s.logger.WithError(err).WithField("raw_data", event.Data).Error("[EdgeServer] Failed to unmarshal SSE event")
+			unmarshalErr := fmt.Errorf("failed to unmarshal event: %w", err)
+			if s.config.SkipMalformedEvents {
+				if s.config.OnEventError != nil {
+					s.config.OnEventError(unmarshalErr)
+				}
+				return
 			}
+			s.emit(models.GenericBotSseEventWrapper{
+				Event:           nil,
+				ConnectionError: unmarshalErr,
+			})
 		} else {
-			log.WithFields(log.Fields{
+			s.logger.WithFields(log.Fields{
 				"event_type": edgeEvent.EventType,
 				"request_id": edgeEvent.RequestId,
 				"event_id":   edgeEvent.EventId,
 			}).Debug("[EdgeServer] Parsed SSE event")
 
-			s.eventChan <- models.GenericBotSseEventWrapper{
+			if edgeEvent.RequestId != "" {
+				s.requestID.Store(edgeEvent.RequestId)
+			}
+
+			if s.resume != nil && edgeEvent.EventId != "" {
+				// The server may replay events the reconnect resumed from.
+				if _, seen := s.seenEventIDs[edgeEvent.EventId]; seen {
+					s.logger.WithField("event_id", edgeEvent.EventId).Debug("[EdgeServer] Skipping replayed SSE event")
+					return
+				}
+				s.seenEventIDs[edgeEvent.EventId] = struct{}{}
+				// Queued events were received already, so resume from the
+				// newest one rather than the last one Next returned.
+				s.resume.setLastEventID(edgeEvent.EventId)
+			}
+
+			if err := s.order.check(&edgeEvent); err != nil {
+				s.finished.Store(true)
+				s.emit(models.GenericBotSseEventWrapper{ConnectionError: err})
+				s.cancel()
+				return
+			}
+
+			s.hook.event(string(edgeEvent.EventType))
+			if s.ring != nil {
+				s.ring.add(&edgeEvent)
+			}
+			s.references.observe(&edgeEvent)
+			reportProcessLog(s.config, &edgeEvent)
+			s.counter.observe(&edgeEvent)
+
+			terminal := edgeEvent.EventType == models.SseEventTypeRunDone || edgeEvent.EventType == models.SseEventTypeRunError ||
+				(s.doneWhen != nil && s.doneWhen(&edgeEvent))
+			if terminal {
+				s.finished.Store(true)
+			}
+
+			s.emit(models.GenericBotSseEventWrapper{
 				Event:           &edgeEvent,
 				ConnectionError: nil,
+			})
+
+			// The run is over, or the caller has what it needs; stop the
+			// connection so it is not retried.
+			if terminal {
+				s.cancel()
 			}
 		}
 	})
 
 	// Start connection in a goroutine
 	go func() {
+		defer close(s.stopped)
 		defer close(s.eventChan)
-		if err := s.connection.Connect(); !errors.Is(err, io.EOF) {
-			log.WithError(err).Error("[EdgeServer] SSE connection failed")
-			s.eventChan <- models.GenericBotSseEventWrapper{
-				Event:           nil,
-				ConnectionError: fmt.Errorf("SSE connection failed: %w", err),
-			}
-		} else {
-			log.Debug("[EdgeServer] SSE connection closed normally")
+		err := s.connection.Connect()
+		if errors.Is(err, io.EOF) || s.finished.Load() || s.connCtx.Err() != nil {
+			s.logger.Debug("[EdgeServer] SSE connection closed normally")
+			s.hook.done(nil)
+			return
 		}
+		s.logger.WithError(err).Error("[EdgeServer] SSE connection failed")
+		connErr := requestError(s.ctx, "SSE connection failed", err)
+		s.hook.done(connErr)
+		s.emit(models.GenericBotSseEventWrapper{
+			Event:           nil,
+			ConnectionError: connErr,
+		})
 	}()
 
 	return nil
 }
 
+// emit queues an event for Next, giving up once the connection is stopped so
+// the producer never blocks on a consumer that went away. Snapshot streams
+// never wait at all: when the queue is full the oldest queued event is
+// dropped to make room.
+func (s *botProviderStream) emit(ev models.GenericBotSseEventWrapper) {
+	if s.ring != nil {
+		for {
+			select {
+			case s.eventChan <- ev:
+				return
+			default:
+			}
+			select {
+			case <-s.eventChan:
+			default:
+			}
+		}
+	}
+
+	select {
+	case s.eventChan <- ev:
+	case <-s.connCtx.Done():
+	}
+}
+
 // Next advances to the next event. Returns false if there are no more events or an error occurred.
 func (s *botProviderStream) Next() bool {
 	s.mu.Lock()
@@ -166,6 +422,9 @@ func (s *botProviderStream) Next() bool {
 	case ev, ok := <-s.eventChan:
 		if !ok {
 			// Channel closed, no more events
+			if err := s.ctx.Err(); err != nil {
+				s.err = err
+			}
 			return false
 		}
 
@@ -176,7 +435,7 @@ func (s *botProviderStream) Next() bool {
 
 		// Check for run error events
 		if ev.Event.EventType == models.SseEventTypeRunError {
-			s.err = fmt.Errorf("SSE stream error: %s", ev.Event.Fact.RunError.Error)
+			s.err = fmt.Errorf("SSE stream error: %w", runErrorDetail(ev.Event))
 			return false
 		}
 
@@ -189,6 +448,21 @@ func (s *botProviderStream) Next() bool {
 	}
 }
 
+// RequestID returns the RequestId of the events received so far.
+func (s *botProviderStream) RequestID() string {
+	id, _ := s.requestID.Load().(string)
+	return id
+}
+
+// Snapshot returns the latest SnapshotSize events received, oldest first, or
+// nil when SnapshotSize is not set.
+func (s *botProviderStream) Snapshot() []*models.GenericBotSseEvent {
+	if s.ring == nil {
+		return nil
+	}
+	return s.ring.snapshot()
+}
+
 // Current returns the current event. Should only be called after Next() returns true.
 func (s *botProviderStream) Current() *models.GenericBotSseEvent {
 	s.mu.Lock()
@@ -205,6 +479,10 @@ func (s *botProviderStream) Err() error {
 
 // Close closes the stream and cleans up resources
 func (s *botProviderStream) Close() error {
+	// Stop the connection first: Next holds the lock while it waits for
+	// events and only returns once the producer goroutine winds down.
+	s.cancel()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -213,9 +491,52 @@ func (s *botProviderStream) Close() error {
 	}
 
 	s.closed = true
+	s.counter.report()
 
 	// Clear current event reference to help GC
 	s.currentEvent = nil
 
 	return nil
 }
+
+// CloseWithTimeout closes the stream and waits up to d for the connection
+// goroutine to exit.
+func (s *botProviderStream) CloseWithTimeout(d time.Duration) error {
+	err := s.Close()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-s.stopped:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("SSE stream did not stop within %s: %w", d, context.DeadlineExceeded)
+	}
+}
+
+// runErrorDetail returns the typed error carried by a run error event. Events
+// without a fact or with a zero-value detail still yield an *ErrorDetail, so
+// callers can always rely on errors.As.
+func runErrorDetail(event *models.GenericBotSseEvent) *models.ErrorDetail {
+	if event.Fact.RunError == nil {
+		return &models.ErrorDetail{Message: "run error event without error detail"}
+	}
+	detail := event.Fact.RunError.Error
+	if detail.Code == "" && detail.Message == "" && detail.Inner == "" {
+		detail.Message = "run error event without error detail"
+	}
+	return &detail
+}
+
+// sseMaxRetries maps SSEMaxRetries onto go-sse's Backoff.MaxRetries, where
+// negative means no retries and zero means retrying forever.
+func sseMaxRetries(n int) int {
+	switch {
+	case n == 0:
+		return -1
+	case n < 0:
+		return 0
+	default:
+		return n
+	}
+}
@@ -0,0 +1,127 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/testutil"
+)
+
+// sseStep is one step of a scripted SSE response: an event to send, or a
+// stall before the next one.
+type sseStep struct {
+	event *models.GenericBotSseEvent
+	stall time.Duration
+}
+
+// newSSEServer serves every request with the scripted steps. A stall ends
+// early when the client goes away.
+func newSSEServer(t *testing.T, steps ...sseStep) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		for _, step := range steps {
+			if step.stall > 0 {
+				select {
+				case <-time.After(step.stall):
+				case <-r.Context().Done():
+					return
+				}
+				continue
+			}
+			data, err := json.Marshal(step.event)
+			if err != nil {
+				t.Errorf("failed to marshal event: %v", err)
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newStreamConfig(url string, httpClient *http.Client) *client.BotProviderConfig {
+	return &client.BotProviderConfig{
+		EdgeServerHost:    url,
+		Namespace:         "default",
+		BotProviderName:   "test-bot",
+		BotProviderApiKey: "test-key",
+		HTTPClient:        httpClient,
+	}
+}
+
+func testMessage() *models.GenericBotMessage {
+	return &models.GenericBotMessage{CustomChannelId: "channel-1", Text: "hello"}
+}
+
+func TestStreamOutlivesHTTPClientTimeout(t *testing.T) {
+	events := testutil.NewEventBuilder()
+	srv := newSSEServer(t,
+		sseStep{event: events.RunInit()},
+		sseStep{stall: 300 * time.Millisecond},
+		sseStep{event: events.RunDone()},
+	)
+
+	// The stall is longer than the client's Timeout, which must not apply
+	// to the stream.
+	config := newStreamConfig(srv.URL, &http.Client{Timeout: 100 * time.Millisecond})
+	stream, err := client.NewStreaming(context.Background(), config, testMessage())
+	if err != nil {
+		t.Fatalf("NewStreaming: %v", err)
+	}
+	defer stream.Close()
+
+	var got []models.SseEventType
+	for stream.Next() {
+		got = append(got, stream.Current().EventType)
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("expected the stream to end without error, got %v", err)
+	}
+	if want := []models.SseEventType{models.SseEventTypeRunInit, models.SseEventTypeRunDone}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected events %v, got %v", want, got)
+	}
+}
+
+func TestStreamStallPastContextDeadline(t *testing.T) {
+	events := testutil.NewEventBuilder()
+	srv := newSSEServer(t,
+		sseStep{event: events.RunInit()},
+		sseStep{stall: 5 * time.Second},
+		sseStep{event: events.RunDone()},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	config := newStreamConfig(srv.URL, &http.Client{Timeout: time.Minute})
+	stream, err := client.NewStreaming(ctx, config, testMessage())
+	if err != nil {
+		t.Fatalf("NewStreaming: %v", err)
+	}
+	defer stream.Close()
+
+	var n int
+	for stream.Next() {
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("expected the event before the stall, got %d events", n)
+	}
+	if err := stream.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected error wrapping context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tmaxmax/go-sse"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// syncSSEStream is a BotProviderStreamer that reads SSE events straight off
+// the response body in Next, without a producer goroutine or event queue. It
+// never reconnects: a dropped connection ends the stream with an error.
+type syncSSEStream struct {
+	ctx          context.Context
+	connCtx      context.Context
+	cancel       context.CancelFunc
+	config       *BotProviderConfig
+	logger       log.FieldLogger
+	body         io.ReadCloser
+	scanner      *bufio.Scanner
+	currentEvent *models.GenericBotSseEvent
+	err          error
+	done         bool
+	closed       bool
+	hook         *requestHook
+	references   *referenceTracker
+	counter      *eventCounter
+	order        *eventOrderChecker
+	doneWhen     func(event *models.GenericBotSseEvent) bool
+	requestID    atomic.Value
+	mu           sync.Mutex
+}
+
+// newSyncSSEStream connects an SSE stream that POSTs r.payload to r.path and
+// is read synchronously by Next.
+func newSyncSSEStream(ctx context.Context, config *BotProviderConfig, r sseRequest) (BotProviderStreamer, error) {
+	if r.contentType == "" {
+		r.contentType = defaultJSONContentType
+	}
+
+	stream := &syncSSEStream{
+		ctx:        ctx,
+		config:     config,
+		logger:     loggerFor(ctx, config),
+		references: newReferenceTracker(config),
+		counter:    newEventCounter(config),
+		doneWhen:   newDonePredicate(config),
+	}
+	stream.order = newEventOrderChecker(config, stream.logger)
+	// The connection is made with the context carrying the stream's span.
+	var spanCtx context.Context
+	spanCtx, stream.hook = startRequest(ctx, config, r.endpoint)
+	stream.connCtx, stream.cancel = context.WithCancel(spanCtx)
+
+	if err := stream.connect(r); err != nil {
+		stream.cancel()
+		err = fmt.Errorf("failed to establish SSE connection: %w", err)
+		stream.hook.done(err)
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// connect sends the request and checks that the response is an event stream.
+func (s *syncSSEStream) connect(r sseRequest) error {
+	req, err := newSSERequest(s.connCtx, s.ctx, s.config, s.logger, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	resp, err := streamHTTPClient(s.config).Do(req)
+	if err != nil {
+		return requestError(s.ctx, "SSE connection failed", err)
+	}
+	s.hook.status = resp.StatusCode
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return newAPIError(s.config, "stream", resp, body)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		resp.Body.Close()
+		return fmt.Errorf("expected Content-Type text/event-stream, got %q", resp.Header.Get("Content-Type"))
+	}
+
+	// The read buffer grows up to the max token size, like the buffered
+	// stream's.
+	maxToken := streamMaxTokenSize(s.config)
+	s.body = resp.Body
+	s.scanner = bufio.NewScanner(resp.Body)
+	s.scanner.Buffer(make([]byte, 0, min(defaultStreamReadBuffer, maxToken)), maxToken)
+	return nil
+}
+
+// Next reads the connection until the next event is decoded. Returns false if
+// there are no more events or an error occurred.
+func (s *syncSSEStream) Next() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || s.done || s.err != nil {
+		return false
+	}
+
+	for {
+		event, err := s.readEvent()
+		if err != nil {
+			s.finish(s.readError(err))
+			return false
+		}
+
+		edgeEvent, ok := s.decode(event)
+		if s.err != nil {
+			s.finish(s.err)
+			return false
+		}
+		if !ok {
+			continue
+		}
+
+		if edgeEvent.EventType == models.SseEventTypeRunError {
+			s.finish(fmt.Errorf("SSE stream error: %w", runErrorDetail(edgeEvent)))
+			return false
+		}
+
+		s.currentEvent = edgeEvent
+		if edgeEvent.EventType == models.SseEventTypeRunDone || (s.doneWhen != nil && s.doneWhen(edgeEvent)) {
+			// The run is over, or the caller has what it needs; the next
+			// call reports the end of the stream.
+			s.finish(nil)
+		}
+		return true
+	}
+}
+
+// readEvent reads the lines of the next event up to the blank line that
+// dispatches it. It returns io.EOF when the connection ends.
+func (s *syncSSEStream) readEvent() (sse.Event, error) {
+	var (
+		event   sse.Event
+		data    strings.Builder
+		hasData bool
+	)
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		if line == "" {
+			if !hasData {
+				event.Type = ""
+				continue
+			}
+			event.Data = data.String()
+			return event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Type = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			event.LastEventID = value
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return sse.Event{}, err
+	}
+	return sse.Event{}, io.EOF
+}
+
+// decode parses event, running the same observers as the buffered stream. It
+// returns false for events that are skipped, and sets s.err for events that
+// end the stream.
+func (s *syncSSEStream) decode(event sse.Event) (*models.GenericBotSseEvent, bool) {
+	s.logger.WithFields(log.Fields{
+		"event_type": event.Type,
+		"event_data": event.Data,
+	}).Debug("[EdgeServer] Received SSE event")
+	dumpRawEvent(s.config.DumpRawTo, s.logger, event)
+
+	var edgeEvent models.GenericBotSseEvent
+	if err := json.Unmarshal([]byte(event.Data), &edgeEvent); err != nil {
+		s.logger.WithError(err).WithField("raw_data", event.Data).Error("[EdgeServer] Failed to unmarshal SSE event")
+		unmarshalErr := fmt.Errorf("failed to unmarshal event: %w", err)
+		if s.config.SkipMalformedEvents {
+			if s.config.OnEventError != nil {
+				s.config.OnEventError(unmarshalErr)
+			}
+			return nil, false
+		}
+		s.err = unmarshalErr
+		return nil, false
+	}
+
+	s.logger.WithFields(log.Fields{
+		"event_type": edgeEvent.EventType,
+		"request_id": edgeEvent.RequestId,
+		"event_id":   edgeEvent.EventId,
+	}).Debug("[EdgeServer] Parsed SSE event")
+
+	if edgeEvent.RequestId != "" {
+		s.requestID.Store(edgeEvent.RequestId)
+	}
+	if err := s.order.check(&edgeEvent); err != nil {
+		s.err = err
+		return nil, false
+	}
+
+	s.hook.event(string(edgeEvent.EventType))
+	s.references.observe(&edgeEvent)
+	reportProcessLog(s.config, &edgeEvent)
+	s.counter.observe(&edgeEvent)
+	return &edgeEvent, true
+}
+
+// readError maps an error reading the connection to the stream error: none
+// when the connection ended normally or was closed, the context error when
+// the context is done.
+func (s *syncSSEStream) readError(err error) error {
+	if errors.Is(err, io.EOF) || s.connCtx.Err() != nil {
+		s.logger.Debug("[EdgeServer] SSE connection closed normally")
+		return s.ctx.Err()
+	}
+	s.logger.WithError(err).Error("[EdgeServer] SSE connection failed")
+	return requestError(s.ctx, "SSE connection failed", err)
+}
+
+// finish ends the stream with err and releases the connection.
+func (s *syncSSEStream) finish(err error) {
+	s.done = true
+	s.err = err
+	s.cancel()
+	s.body.Close()
+	s.hook.done(err)
+}
+
+// RequestID returns the RequestId of the events received so far.
+func (s *syncSSEStream) RequestID() string {
+	id, _ := s.requestID.Load().(string)
+	return id
+}
+
+// Current returns the current event. Should only be called after Next() returns true.
+func (s *syncSSEStream) Current() *models.GenericBotSseEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.currentEvent
+}
+
+// Err returns any error that occurred during streaming
+func (s *syncSSEStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close closes the stream and the connection. It may be called while another
+// goroutine is blocked in Next, which then returns false.
+func (s *syncSSEStream) Close() error {
+	// Cancelling the request unblocks a read in progress in Next.
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+	if !s.done {
+		s.done = true
+		s.body.Close()
+		s.hook.done(nil)
+	}
+	s.counter.report()
+	s.currentEvent = nil
+
+	return nil
+}
+
+// CloseWithTimeout closes the stream like Close. There is no connection
+// goroutine to wait for, so d is not used.
+func (s *syncSSEStream) CloseWithTimeout(d time.Duration) error {
+	return s.Close()
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// Tenant is the routing of one bot provider. Empty fields fall back to the
+// base config of the ContextClient.
+type Tenant struct {
+	EdgeServerHost    string
+	Namespace         string
+	BotProviderName   string
+	BotProviderApiKey string
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx carrying tenant. Calls made through a
+// client created with NewContextClient are routed to it.
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant carried by ctx, if any.
+func TenantFromContext(ctx context.Context) (Tenant, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(Tenant)
+	return tenant, ok
+}
+
+// ContextClient is a Client that resolves its routing from the context of
+// every call, so a multi-tenant service can share one client, and its
+// connection pool, across tenants.
+type ContextClient struct {
+	base    *BotProviderConfig
+	limiter *priorityLimiter
+}
+
+// NewContextClient creates a Client whose calls are routed to the Tenant
+// carried by their context (see WithTenant). Everything else, from the HTTP
+// clients to the hooks, comes from base. Calls without a tenant use base's
+// own routing.
+func NewContextClient(base *BotProviderConfig) Client {
+	if base == nil {
+		base = &BotProviderConfig{}
+	}
+	// Apply the client defaults once so every tenant shares them.
+	NewBotProviderClientWithConfig(base)
+	return &ContextClient{base: base, limiter: newSendLimiter(base)}
+}
+
+// clientFor returns a BotProviderClient bound to the tenant of ctx.
+func (c *ContextClient) clientFor(ctx context.Context) (*BotProviderClient, error) {
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return &BotProviderClient{config: c.base, limiter: c.limiter}, nil
+	}
+
+	config := cloneConfig(c.base)
+	if tenant.EdgeServerHost != "" {
+		config.EdgeServerHost = tenant.EdgeServerHost
+	}
+	if tenant.Namespace != "" {
+		config.Namespace = tenant.Namespace
+	}
+	if tenant.BotProviderName != "" {
+		config.BotProviderName = tenant.BotProviderName
+	}
+	if tenant.BotProviderApiKey != "" {
+		config.BotProviderApiKey = tenant.BotProviderApiKey
+	}
+	// Failover endpoints carry the base tenant's keys.
+	if tenant.EdgeServerHost != "" || tenant.BotProviderApiKey != "" {
+		config.FailoverEndpoints = nil
+	}
+
+	if config.EdgeServerHost == "" || config.Namespace == "" || config.BotProviderName == "" {
+		return nil, fmt.Errorf("tenant routing is incomplete: EdgeServerHost, Namespace and BotProviderName must be set")
+	}
+	return &BotProviderClient{config: config, limiter: c.limiter}, nil
+}
+
+func (c *ContextClient) NewStreamer(ctx context.Context, message *models.GenericBotMessage) (BotProviderStreamer, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewStreamer(ctx, message)
+}
+
+func (c *ContextClient) NewConversationStreamer(ctx context.Context, message *models.GenericBotMessage) (ConversationStreamer, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewConversationStreamer(ctx, message)
+}
+
+func (c *ContextClient) SendMessage(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (*models.GenericBotReply, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.SendMessage(ctx, message, isDebug)
+}
+
+func (c *ContextClient) ReadMessages(ctx context.Context, message *models.GenericBotMessage, isDebug bool) (MessageReader, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.ReadMessages(ctx, message, isDebug)
+}
+
+func (c *ContextClient) GetChannelHistory(ctx context.Context, customChannelID string, limit int, before *string) ([]models.BufferedMessage, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetChannelHistory(ctx, customChannelID, limit, before)
+}
+
+func (c *ContextClient) TriggerJSON(ctx context.Context, payload map[string]interface{}) (interface{}, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.TriggerJSON(ctx, payload)
+}
+
+func (c *ContextClient) TriggerJSONStream(ctx context.Context, payload map[string]interface{}) (BotProviderStreamer, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.TriggerJSONStream(ctx, payload)
+}
+
+func (c *ContextClient) TriggerForm(ctx context.Context, payload map[string]interface{}, reader io.Reader, filename string, mime *string) (interface{}, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.TriggerForm(ctx, payload, reader, filename, mime)
+}
+
+func (c *ContextClient) UploadBlob(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.UploadBlob(ctx, customChannelID, reader, filename, mime)
+}
+
+func (c *ContextClient) UploadBlobWithStats(ctx context.Context, customChannelID string, reader io.Reader, filename string, mime *string) (*models.Blob, UploadStats, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, UploadStats{}, err
+	}
+	return client.UploadBlobWithStats(ctx, customChannelID, reader, filename, mime)
+}
+
+func (c *ContextClient) UploadBlobs(ctx context.Context, customChannelID string, files []BlobUpload) ([]models.Blob, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.UploadBlobs(ctx, customChannelID, files)
+}
+
+func (c *ContextClient) ListBlobs(ctx context.Context, customChannelID string) ([]models.Blob, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.ListBlobs(ctx, customChannelID)
+}
+
+func (c *ContextClient) CancelProcess(ctx context.Context, requestID, processID string) error {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	return client.CancelProcess(ctx, requestID, processID)
+}
+
+func (c *ContextClient) CancelRun(ctx context.Context, customChannelID, requestID string) error {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	return client.CancelRun(ctx, customChannelID, requestID)
+}
+
+func (c *ContextClient) VerifyAPIKey(ctx context.Context) (bool, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return false, err
+	}
+	return client.VerifyAPIKey(ctx)
+}
+
+func (c *ContextClient) DownloadReplyAttachments(ctx context.Context, reply *models.GenericBotReply) ([]DownloadedBlob, error) {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DownloadReplyAttachments(ctx, reply)
+}
+
+func (c *ContextClient) Ping(ctx context.Context) error {
+	client, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Ping(ctx)
+}
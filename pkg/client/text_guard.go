@@ -0,0 +1,48 @@
+package client
+
+import (
+	"unicode/utf8"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// TruncatedTextMarker is appended to message text cut short by
+// MaxMessageTextBytes.
+const TruncatedTextMarker = "…[truncated]"
+
+// truncateText cuts text to at most max bytes, marker included, without
+// splitting a UTF-8 sequence. A max too small for the marker cuts the text
+// without one. It reports whether text was cut.
+func truncateText(text string, max int) (string, bool) {
+	if max <= 0 || len(text) <= max {
+		return text, false
+	}
+	if max < len(TruncatedTextMarker) {
+		return text[:runeBoundary(text, max)], true
+	}
+	keep := runeBoundary(text, max-len(TruncatedTextMarker))
+	return text[:keep] + TruncatedTextMarker, true
+}
+
+// runeBoundary returns the largest n <= i at which text can be cut without
+// splitting a UTF-8 sequence. i must be less than len(text).
+func runeBoundary(text string, i int) int {
+	for i > 0 && !utf8.RuneStart(text[i]) {
+		i--
+	}
+	return i
+}
+
+// guardMessageText applies a MaxMessageTextBytes limit to msg, calling
+// onTruncated with the message ID and original size when it cuts the text.
+func guardMessageText(msg *models.BufferedMessage, max int, onTruncated func(messageID string, size int)) {
+	size := len(msg.Text)
+	text, cut := truncateText(msg.Text, max)
+	if !cut {
+		return
+	}
+	msg.Text = text
+	if onTruncated != nil {
+		onTruncated(msg.MessageId, size)
+	}
+}
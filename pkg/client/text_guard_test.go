@@ -0,0 +1,63 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+func TestMaxMessageTextBytes(t *testing.T) {
+	const text = "héllo wörld, this text is longer than the marker"
+	tests := []struct {
+		max  int
+		want string
+	}{
+		{0, text},
+		{len(text), text},
+		{20, "héllo" + client.TruncatedTextMarker},
+		{len(client.TruncatedTextMarker), client.TruncatedTextMarker},
+		{5, "héll"},
+		{2, "h"},
+		{1, "h"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"isSuccess": true,
+					"data":      map[string]interface{}{"messages": []map[string]string{{"messageId": "m1", "text": text}}},
+				})
+			}))
+			defer srv.Close()
+
+			transport := &http.Transport{}
+			t.Cleanup(transport.CloseIdleConnections)
+			c := client.NewBotProviderClientWithConfig(&client.BotProviderConfig{
+				EdgeServerHost:      srv.URL,
+				Namespace:           "default",
+				BotProviderName:     "test-bot",
+				BotProviderApiKey:   "test-key",
+				HTTPClient:          &http.Client{Transport: transport},
+				MaxMessageTextBytes: tc.max,
+			})
+
+			reply, err := c.SendMessage(context.Background(), &models.GenericBotMessage{CustomChannelId: "ch", Text: "hi"}, false)
+			if err != nil {
+				t.Fatalf("SendMessage: %v", err)
+			}
+			got := reply.Messages[0].Text
+			if got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+			if tc.max > 0 && len(got) > tc.max {
+				t.Fatalf("expected at most %d bytes, got %d", tc.max, len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// withDefaultTimeout returns ctx bounded by d unless d is zero or ctx already
+// has a deadline, in which case ctx is returned as-is.
+func withDefaultTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
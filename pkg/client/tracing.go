@@ -0,0 +1,44 @@
+package client
+
+import "context"
+
+// Span attribute keys set by the SDK.
+const (
+	SpanAttrNamespace       = "asgard.namespace"
+	SpanAttrBotProviderName = "asgard.bot_provider_name"
+	SpanAttrEndpoint        = "asgard.endpoint"
+	SpanAttrStatusCode      = "http.response.status_code"
+)
+
+// Tracer starts spans for the SDK's operations. It mirrors the small part of
+// the OpenTelemetry API the SDK needs, so tracing can be wired in without the
+// SDK depending on OpenTelemetry: an adapter over an otel trace.Tracer maps
+// Start to tracer.Start with the attributes converted to attribute.KeyValue,
+// and Span to the returned trace.Span.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	// AddEvent records a point in time on the span; streams add one per
+	// event received, named after the event type.
+	AddEvent(name string, attrs map[string]interface{})
+	RecordError(err error)
+	End()
+}
+
+// startSpan starts the span of an operation on endpoint and returns it with
+// the context carrying it, which the operation's requests are made with. It
+// returns ctx and a nil span when no Tracer is configured.
+func startSpan(ctx context.Context, config *BotProviderConfig, endpoint string) (context.Context, Span) {
+	if config.Tracer == nil {
+		return ctx, nil
+	}
+	return config.Tracer.Start(ctx, "asgard "+endpoint, map[string]interface{}{
+		SpanAttrNamespace:       config.Namespace,
+		SpanAttrBotProviderName: config.BotProviderName,
+		SpanAttrEndpoint:        endpoint,
+	})
+}
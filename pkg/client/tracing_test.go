@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+type spanKey struct{}
+
+// fakeTracer records the spans it starts and stores each in the context it
+// returns.
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, client.Span) {
+	span := &fakeSpan{name: name}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+type fakeSpan struct{ name string }
+
+func (s *fakeSpan) SetAttribute(key string, value interface{})         {}
+func (s *fakeSpan) AddEvent(name string, attrs map[string]interface{}) {}
+func (s *fakeSpan) RecordError(err error)                              {}
+func (s *fakeSpan) End()                                               {}
+
+// spanTransport records the span carried by the context of each request.
+type spanTransport struct {
+	next  http.RoundTripper
+	mu    sync.Mutex
+	spans []interface{}
+}
+
+func (t *spanTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.spans = append(t.spans, r.Context().Value(spanKey{}))
+	t.mu.Unlock()
+	return t.next.RoundTrip(r)
+}
+
+func TestRequestsCarryTheirSpan(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"isSuccess":true,"data":{"messages":[]}}`))
+	}))
+	defer srv.Close()
+
+	tracer := &fakeTracer{}
+	base := &http.Transport{}
+	t.Cleanup(base.CloseIdleConnections)
+	transport := &spanTransport{next: base}
+	c := client.NewBotProviderClientWithConfig(&client.BotProviderConfig{
+		EdgeServerHost:    srv.URL,
+		Namespace:         "default",
+		BotProviderName:   "test-bot",
+		BotProviderApiKey: "test-key",
+		HTTPClient:        &http.Client{Transport: transport},
+		Tracer:            tracer,
+	})
+
+	if _, err := c.SendMessage(context.Background(), &models.GenericBotMessage{CustomChannelId: "ch", Text: "hi"}, false); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if _, err := c.TriggerJSON(context.Background(), map[string]interface{}{"key": "value"}); err != nil {
+		t.Fatalf("TriggerJSON: %v", err)
+	}
+
+	if len(tracer.spans) != 2 || len(transport.spans) != 2 {
+		t.Fatalf("expected 2 spans and 2 requests, got %d and %d", len(tracer.spans), len(transport.spans))
+	}
+	for i, span := range tracer.spans {
+		if transport.spans[i] != span {
+			t.Fatalf("expected request %d to carry span %q, got %v", i, span.name, transport.spans[i])
+		}
+	}
+}
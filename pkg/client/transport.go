@@ -0,0 +1,95 @@
+package client
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultSSEDialTimeout = 10 * time.Second
+	defaultSSEKeepAlive   = 30 * time.Second
+)
+
+// newSSEHTTPClient builds the HTTP client used for SSE when the caller did not
+// supply one. SSE connections are long-lived, so the client has no overall
+// Timeout; only connection establishment is bounded, and TCP keepalives keep
+// idle streams from being dropped by intermediaries.
+func newSSEHTTPClient(config *BotProviderConfig) *http.Client {
+	return &http.Client{Transport: config.TransportPool.transport(config, true, func() *http.Transport {
+		return newSSETransport(config)
+	})}
+}
+
+func newSSETransport(config *BotProviderConfig) *http.Transport {
+	dialTimeout := config.SSEDialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = defaultSSEDialTimeout
+	}
+	keepAlive := config.SSEKeepAlive
+	if keepAlive == 0 {
+		keepAlive = defaultSSEKeepAlive
+	}
+
+	transport := newTransport(config)
+	transport.DialContext = (&net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: keepAlive,
+	}).DialContext
+	return transport
+}
+
+// newTransport clones the default transport, enforcing the configured minimum
+// TLS version and SNI server name.
+func newTransport(config *BotProviderConfig) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: minTLSVersion(config),
+		ServerName: config.ServerName,
+	}
+	return transport
+}
+
+func minTLSVersion(config *BotProviderConfig) uint16 {
+	if config.MinTLSVersion == 0 {
+		return tls.VersionTLS12
+	}
+	return config.MinTLSVersion
+}
+
+// newDownloadHTTPClient builds the HTTP client used for downloads when the
+// caller did not supply one. Media is fetched from arbitrary hosts, so the
+// transport keeps the minimum TLS version but not the SNI server name, which
+// only holds for EdgeServer.
+func newDownloadHTTPClient(config *BotProviderConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: minTLSVersion(config)}
+	return &http.Client{Timeout: defaultHTTPTimeout, Transport: transport}
+}
+
+// applyRequestOverrides applies the configured Host header override to req,
+// and the request ID carried by its context.
+func applyRequestOverrides(req *http.Request, config *BotProviderConfig) {
+	if config.HostHeader != "" {
+		req.Host = config.HostHeader
+	}
+	if id, ok := RequestIDFromContext(req.Context()); ok {
+		req.Header.Set(RequestIDHeader, id)
+	}
+}
+
+// withoutTimeout returns client unchanged when it has no overall Timeout, or a
+// shallow copy with the Timeout cleared otherwise. http.Client.Timeout covers
+// reading the whole response body, which would kill a long-running SSE stream
+// mid-flight; cancellation of streams is left to the request context. The
+// copy shares the original Transport, so connection pooling and TLS settings
+// are kept.
+func withoutTimeout(client *http.Client) *http.Client {
+	if client.Timeout == 0 {
+		return client
+	}
+	clone := *client
+	clone.Timeout = 0
+	return &clone
+}
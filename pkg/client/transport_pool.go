@@ -0,0 +1,70 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+)
+
+// TransportPool lets clients share HTTP transports, and with them their
+// keep-alive connection pools. Clients whose config names the same pool and
+// EdgeServerHost reuse one transport for requests and one for SSE streams; a
+// pool created with isolateNamespaces also keys transports by Namespace.
+//
+// Sharing saves connections and TLS handshakes when a process talks to many
+// namespaces on one host. Isolating gives each namespace its own
+// connections, so a namespace holding many long-lived streams cannot exhaust
+// the per-host connection limits or idle pool of the others, at the cost of
+// more connections. A transport is built from the config of the first
+// client that needs it, so its TLS and dialer settings apply to every client
+// sharing it. The pool is not used for caller-supplied HTTP clients.
+type TransportPool struct {
+	isolateNamespaces bool
+	mu                sync.Mutex
+	transports        map[transportKey]*http.Transport
+}
+
+type transportKey struct {
+	host      string
+	namespace string
+	sse       bool
+}
+
+// NewTransportPool creates an empty TransportPool.
+func NewTransportPool(isolateNamespaces bool) *TransportPool {
+	return &TransportPool{
+		isolateNamespaces: isolateNamespaces,
+		transports:        make(map[transportKey]*http.Transport),
+	}
+}
+
+// transport returns the pooled transport for config, creating it with build
+// on first use. A nil pool always builds a new transport.
+func (p *TransportPool) transport(config *BotProviderConfig, sse bool, build func() *http.Transport) *http.Transport {
+	if p == nil {
+		return build()
+	}
+
+	key := transportKey{host: config.EdgeServerHost, sse: sse}
+	if p.isolateNamespaces {
+		key.namespace = config.Namespace
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t, ok := p.transports[key]; ok {
+		return t
+	}
+	t := build()
+	p.transports[key] = t
+	return t
+}
+
+// CloseIdleConnections closes the idle connections of every pooled
+// transport.
+func (p *TransportPool) CloseIdleConnections() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, t := range p.transports {
+		t.CloseIdleConnections()
+	}
+}
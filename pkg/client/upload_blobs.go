@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// BlobUpload is one file of an UploadBlobs call.
+type BlobUpload struct {
+	Reader   io.Reader
+	Filename string
+	// Mime is the content type of the file; nil detects it from the content,
+	// like UploadBlob.
+	Mime *string
+}
+
+// UploadBlobs uploads files to customChannelID in a single request, one
+// multipart part per file, and returns their blobs in the order of files.
+// Like UploadBlob the files are streamed from a separate goroutine, one after
+// the other, so none of them is buffered in memory.
+func (c *BotProviderClient) UploadBlobs(ctx context.Context, customChannelID string, files []BlobUpload) ([]models.Blob, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files to upload")
+	}
+	return c.uploadBlobs(ctx, customChannelID, files, nil)
+}
+
+// orderBlobs returns blobs in the order of files. Blobs are matched to files
+// by name, so a server listing them in another order is handled; blobs
+// without a matching name keep their position.
+func orderBlobs(files []BlobUpload, blobs []models.Blob) []models.Blob {
+	ordered := make([]models.Blob, len(files))
+	matched := make([]bool, len(files))
+	used := make([]bool, len(blobs))
+	for i, f := range files {
+		for j, blob := range blobs {
+			if !used[j] && blob.FileName != nil && *blob.FileName == f.Filename {
+				ordered[i], matched[i], used[j] = blob, true, true
+				break
+			}
+		}
+	}
+
+	j := 0
+	for i := range files {
+		if matched[i] {
+			continue
+		}
+		for used[j] {
+			j++
+		}
+		ordered[i], used[j] = blobs[j], true
+	}
+	return ordered
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// UploadBlobFile uploads the file at path to customChannelID, named after the
+// file's base name. Without a mime type the type is looked up from the file
+// extension; for unknown extensions no type is passed, so UploadBlob detects
+// it from the content. The returned blob's Size is the size of the file when
+// EdgeServer does not report one.
+func (a *botAgent) UploadBlobFile(ctx context.Context, customChannelID, path string, mimeType *string) (*models.Blob, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("failed to upload %s: is a directory", path)
+	}
+
+	if mimeType == nil {
+		if byExt := mime.TypeByExtension(filepath.Ext(path)); byExt != "" {
+			mimeType = &byExt
+		}
+	}
+	blob, err := a.client.UploadBlob(ctx, customChannelID, file, filepath.Base(path), mimeType)
+	if err != nil {
+		return nil, err
+	}
+	if blob != nil && blob.Size == 0 {
+		blob.Size = info.Size()
+	}
+	return blob, nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// UploadStats describes the transfer of an UploadBlobWithStats call.
+type UploadStats struct {
+	// Bytes is the size of the request body sent, multipart framing included.
+	Bytes int64
+	// TransferDuration is the time from the first to the last byte read off
+	// the upload pipe by the HTTP transport.
+	TransferDuration time.Duration
+	// Duration is the time of the whole call, response included.
+	Duration time.Duration
+	// BytesPerSecond is Bytes over TransferDuration, or 0 when nothing was
+	// transferred.
+	BytesPerSecond float64
+}
+
+// transferMeter counts the bytes the transport reads from the upload pipe and
+// when it read them. Reading happens on the transport's goroutine.
+type transferMeter struct {
+	r     io.Reader
+	mu    sync.Mutex
+	n     int64
+	first time.Time
+	last  time.Time
+}
+
+func (m *transferMeter) Read(p []byte) (int, error) {
+	n, err := m.r.Read(p)
+
+	m.mu.Lock()
+	now := time.Now()
+	if m.first.IsZero() {
+		m.first = now
+	}
+	m.last = now
+	m.n += int64(n)
+	m.mu.Unlock()
+
+	return n, err
+}
+
+func (m *transferMeter) stats(total time.Duration) UploadStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := UploadStats{Bytes: m.n, Duration: total}
+	if !m.first.IsZero() {
+		stats.TransferDuration = m.last.Sub(m.first)
+	}
+	if stats.TransferDuration > 0 {
+		stats.BytesPerSecond = float64(m.n) / stats.TransferDuration.Seconds()
+	}
+	return stats
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Validate reports every configuration problem that would otherwise only
+// surface, less clearly, when the first request is sent.
+func (c *BotProviderConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("config cannot be nil")
+	}
+
+	var errs []error
+	if err := validateHost(c.EdgeServerHost); err != nil {
+		errs = append(errs, fmt.Errorf("EdgeServerHost: %w", err))
+	}
+	if err := validatePathSegment(c.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("Namespace: %w", err))
+	}
+	if err := validatePathSegment(c.BotProviderName); err != nil {
+		errs = append(errs, fmt.Errorf("BotProviderName: %w", err))
+	}
+	if c.BotProviderApiKey == "" {
+		errs = append(errs, fmt.Errorf("BotProviderApiKey: must be set"))
+	}
+	for i, ep := range c.FailoverEndpoints {
+		if err := validateHost(ep.Host); err != nil {
+			errs = append(errs, fmt.Errorf("FailoverEndpoints[%d].Host: %w", i, err))
+		}
+		if ep.APIKey == "" {
+			errs = append(errs, fmt.Errorf("FailoverEndpoints[%d].APIKey: must be set", i))
+		}
+	}
+	switch c.Transport {
+	case "", TransportSSE, TransportSSESync, TransportLongPoll:
+	default:
+		errs = append(errs, fmt.Errorf("Transport: unknown transport %q", c.Transport))
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewValidatedBotProviderClient creates a BotProvider API client from config,
+// returning the config's validation errors instead of deferring them to the
+// first request.
+func NewValidatedBotProviderClient(config *BotProviderConfig) (Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid bot provider config: %w", err)
+	}
+	return NewBotProviderClientWithConfig(config), nil
+}
+
+func validateHost(host string) error {
+	if host == "" {
+		return fmt.Errorf("must be set")
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must be an absolute http(s) URL, got %q", host)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("missing host in %q", host)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("must not contain a query or fragment")
+	}
+	if strings.HasSuffix(u.Path, "/") {
+		return fmt.Errorf("must not end with '/'")
+	}
+	return nil
+}
+
+func validatePathSegment(s string) error {
+	if s == "" {
+		return fmt.Errorf("must be set")
+	}
+	if url.PathEscape(s) != s {
+		return fmt.Errorf("%q contains characters that are not URL path safe", s)
+	}
+	return nil
+}
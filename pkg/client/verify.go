@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// VerifyAPIKey checks the configured API key against the primary endpoint
+// without sending a message. It lists the blobs of an unused channel, a
+// read-only request EdgeServer only answers for a valid key. It returns true
+// for a success status and false for 401/403. A 404, meaning the namespace or
+// bot provider is unknown, is returned as an error wrapping
+// ErrBotProviderNotFound; any other status, which says nothing about the key,
+// as an *APIError, and connectivity problems as their error.
+func (c *BotProviderClient) VerifyAPIKey(ctx context.Context) (_ bool, err error) {
+	ctx, hook := startRequest(ctx, c.config, HookEndpointVerifyAPIKey)
+	defer func() { hook.done(err) }()
+
+	resp, respBytes, err := c.probe(ctx, hook, "failed to verify api key")
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case isAuthStatus(resp.StatusCode):
+		return false, nil
+	case probeAccepted(resp.StatusCode):
+		return true, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return false, fmt.Errorf("%w: %w", ErrBotProviderNotFound, newAPIError(c.config, "verify api key", resp, respBytes))
+	default:
+		return false, newAPIError(c.config, "verify api key", resp, respBytes)
+	}
+}
+
+// probeChannelID is the channel whose blobs a probe lists. Nothing is ever
+// sent to it, so the listing is empty.
+const probeChannelID = "asgard-sdk-probe"
+
+// probeAccepted reports whether a probe with status code got past
+// authentication; see VerifyAPIKey.
+func probeAccepted(code int) bool {
+	return code >= 200 && code < 300
+}
+
+// probe lists the blobs of probeChannelID on the primary host and returns the
+// response with its body read.
+func (c *BotProviderClient) probe(ctx context.Context, hook *requestHook, action string) (*http.Response, []byte, error) {
+	query := url.Values{"customChannelId": {outgoingChannelID(c.config, probeChannelID)}}
+	u := c.botProviderURL(c.config.EdgeServerHost, "blob") + "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	applyRequestOverrides(req, c.config)
+	req.Header.Set("X-API-KEY", c.config.BotProviderApiKey)
+	applyHeaders(req, c.config, requestOptionsFromContext(ctx))
+
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, requestError(ctx, action, err)
+	}
+	defer resp.Body.Close()
+	hook.status = resp.StatusCode
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, requestError(ctx, "failed to read response body", err)
+	}
+	return resp, respBytes, nil
+}
@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+)
+
+func TestVerifyAPIKeyStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		valid     bool
+		wantErr   bool
+		wantIsErr error
+	}{
+		{http.StatusOK, true, false, nil},
+		{http.StatusUnauthorized, false, false, nil},
+		{http.StatusForbidden, false, false, nil},
+		{http.StatusNotFound, false, true, client.ErrBotProviderNotFound},
+		// A 405 may come from routing before authentication, so it says
+		// nothing about the key.
+		{http.StatusMethodNotAllowed, false, true, nil},
+		{http.StatusBadRequest, false, true, nil},
+		{http.StatusInternalServerError, false, true, nil},
+	}
+	for _, tc := range tests {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/ns/default/bot-provider/test-bot/blob" {
+					t.Errorf("expected a GET of the blob listing, got %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("X-API-KEY"); got != "test-key" {
+					t.Errorf("expected the api key to be sent, got %q", got)
+				}
+				w.WriteHeader(tc.status)
+				if tc.status == http.StatusOK {
+					_, _ = w.Write([]byte(`{"isSuccess":true,"data":[]}`))
+				}
+			}))
+			defer srv.Close()
+
+			var endpoints []string
+			config := newStreamConfig(srv.URL, &http.Client{})
+			config.OnRequestStart = func(endpoint string, ctx context.Context) {
+				endpoints = append(endpoints, endpoint)
+			}
+			c := client.NewBotProviderClientWithConfig(config)
+
+			valid, err := c.VerifyAPIKey(context.Background())
+			if valid != tc.valid {
+				t.Fatalf("expected valid=%v, got %v", tc.valid, valid)
+			}
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error=%v, got %v", tc.wantErr, err)
+			}
+			if tc.wantIsErr != nil && !errors.Is(err, tc.wantIsErr) {
+				t.Fatalf("expected error wrapping %v, got %v", tc.wantIsErr, err)
+			}
+			var apiErr *client.APIError
+			if err != nil && !errors.As(err, &apiErr) {
+				t.Fatalf("expected an *APIError, got %v", err)
+			}
+			if len(endpoints) != 1 || endpoints[0] != client.HookEndpointVerifyAPIKey {
+				t.Fatalf("expected the request to be reported as %q, got %v", client.HookEndpointVerifyAPIKey, endpoints)
+			}
+		})
+	}
+}
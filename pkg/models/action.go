@@ -0,0 +1,116 @@
+package models
+
+import "fmt"
+
+// NewMessageAction creates a MESSAGE action that sends text when tapped.
+func NewMessageAction(text string) MessageTemplateAction {
+	return MessageTemplateAction{Type: MessageTemplateActionTypeMessage, Text: &text}
+}
+
+// NewUriAction creates a URI action that opens uri when tapped.
+func NewUriAction(uri string) MessageTemplateAction {
+	return MessageTemplateAction{Type: MessageTemplateActionTypeUri, Uri: &uri}
+}
+
+// NewEmitAction creates an EMIT action that reports eventName, and payload if
+// non-nil, back to the bot when tapped.
+func NewEmitAction(eventName string, payload interface{}) MessageTemplateAction {
+	action := MessageTemplateAction{Type: MessageTemplateActionTypeEmit, EventName: &eventName}
+	if payload != nil {
+		action.Payload = &payload
+	}
+	return action
+}
+
+// Validate checks that the fields required by the action's Type are set.
+func (a *MessageTemplateAction) Validate() error {
+	switch a.Type {
+	case MessageTemplateActionTypeMessage:
+		if a.Text == nil || *a.Text == "" {
+			return fmt.Errorf("MESSAGE action requires text")
+		}
+	case MessageTemplateActionTypeUri:
+		if a.Uri == nil || *a.Uri == "" {
+			return fmt.Errorf("URI action requires uri")
+		}
+	case MessageTemplateActionTypeEmit:
+		if a.EventName == nil || *a.EventName == "" {
+			return fmt.Errorf("EMIT action requires eventName")
+		}
+	case "":
+		return fmt.Errorf("action type is required")
+	default:
+		return fmt.Errorf("unknown action type %q", a.Type)
+	}
+	return nil
+}
+
+// ToMessage converts a tapped action into the message to send back to the
+// bot. MESSAGE actions send their text, with the action payload when it is a
+// JSON object. EMIT actions send a payload of the form
+// {"eventName": ..., "payload": ...}, the latter only when the action has a
+// payload. URI actions are handled client-side and cannot be converted.
+func (a *MessageTemplateAction) ToMessage(customChannelID, customMessageID string) (*GenericBotMessage, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	msg := &GenericBotMessage{
+		CustomChannelId: customChannelID,
+		CustomMessageId: customMessageID,
+		Action:          PostBackActionNone,
+	}
+
+	switch a.Type {
+	case MessageTemplateActionTypeMessage:
+		msg.Text = *a.Text
+		if a.Payload != nil {
+			if payload, ok := (*a.Payload).(map[string]interface{}); ok {
+				msg.Payload = payload
+			}
+		}
+	case MessageTemplateActionTypeEmit:
+		if a.Text != nil {
+			msg.Text = *a.Text
+		}
+		msg.Payload = map[string]interface{}{"eventName": *a.EventName}
+		if a.Payload != nil && *a.Payload != nil {
+			msg.Payload["payload"] = *a.Payload
+		}
+	default:
+		return nil, fmt.Errorf("%s action cannot be sent as a message", a.Type)
+	}
+
+	return msg, nil
+}
+
+// ToMessage converts a tapped quick reply into the message to send back to
+// the bot. A quick reply with an Action is handled like a button with that
+// action. Otherwise the displayed text is sent, along with the Payload: a
+// JSON object is sent as the message payload and any other value is sent as
+// {"payload": ...}.
+func (q *QuickReply) ToMessage(customChannelID, customMessageID string) (*GenericBotMessage, error) {
+	if q.Action != nil {
+		return q.Action.ToMessage(customChannelID, customMessageID)
+	}
+	if q.Text == "" {
+		return nil, fmt.Errorf("quick reply requires text or an action")
+	}
+
+	msg := &GenericBotMessage{
+		CustomChannelId: customChannelID,
+		CustomMessageId: customMessageID,
+		Text:            q.Text,
+		Action:          PostBackActionNone,
+	}
+
+	switch payload := q.Payload.(type) {
+	case nil:
+	case map[string]interface{}:
+		msg.Payload = payload
+	default:
+		msg.Payload = map[string]interface{}{"payload": payload}
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// emitTemplateJSON is a button template with EMIT actions as data-insight-api
+// sends them.
+const emitTemplateJSON = `{
+	"type": "BUTTON",
+	"text": "Revenue is up 12% this quarter.",
+	"buttons": [
+		{
+			"label": "Show by region",
+			"action": {
+				"type": "EMIT",
+				"text": "Show by region",
+				"uri": null,
+				"eventName": "DRILL_DOWN",
+				"payload": {"dimension": "region", "filters": {"quarter": "2026-Q3"}}
+			}
+		},
+		{
+			"label": "Refresh",
+			"action": {"type": "EMIT", "text": null, "uri": null, "eventName": "REFRESH", "payload": null}
+		}
+	],
+	"quickReplies": [
+		{
+			"text": "Export",
+			"action": {"type": "EMIT", "text": "Export", "uri": null, "eventName": "EXPORT_CSV", "payload": {"format": "csv"}}
+		}
+	]
+}`
+
+func decodeEmitTemplate(t *testing.T) MessageTemplate {
+	t.Helper()
+	var template MessageTemplate
+	if err := json.Unmarshal([]byte(emitTemplateJSON), &template); err != nil {
+		t.Fatalf("failed to decode template: %v", err)
+	}
+	if template.Buttons == nil || len(*template.Buttons) != 2 {
+		t.Fatalf("expected 2 buttons, got %+v", template.Buttons)
+	}
+	if len(template.QuickReplies) != 1 {
+		t.Fatalf("expected 1 quick reply, got %+v", template.QuickReplies)
+	}
+	return template
+}
+
+func TestDecodeEmitAction(t *testing.T) {
+	template := decodeEmitTemplate(t)
+	action := (*template.Buttons)[0].Action
+
+	if action.Type != MessageTemplateActionTypeEmit {
+		t.Errorf("expected type %s, got %s", MessageTemplateActionTypeEmit, action.Type)
+	}
+	if action.EventName == nil || *action.EventName != "DRILL_DOWN" {
+		t.Errorf("expected eventName DRILL_DOWN, got %v", action.EventName)
+	}
+	if action.Text == nil || *action.Text != "Show by region" {
+		t.Errorf("expected text %q, got %v", "Show by region", action.Text)
+	}
+	if action.Uri != nil {
+		t.Errorf("expected no uri, got %q", *action.Uri)
+	}
+	if action.Payload == nil {
+		t.Fatal("expected a payload")
+	}
+	want := map[string]interface{}{"dimension": "region", "filters": map[string]interface{}{"quarter": "2026-Q3"}}
+	if !reflect.DeepEqual(*action.Payload, want) {
+		t.Errorf("expected payload %v, got %v", want, *action.Payload)
+	}
+	if err := action.Validate(); err != nil {
+		t.Errorf("expected the action to be valid, got %v", err)
+	}
+
+	refresh := (*template.Buttons)[1].Action
+	if refresh.EventName == nil || *refresh.EventName != "REFRESH" {
+		t.Errorf("expected eventName REFRESH, got %v", refresh.EventName)
+	}
+	if refresh.Text != nil {
+		t.Errorf("expected no text, got %q", *refresh.Text)
+	}
+}
+
+func TestEmitActionToMessage(t *testing.T) {
+	template := decodeEmitTemplate(t)
+
+	tests := []struct {
+		name        string
+		toMessage   func() (*GenericBotMessage, error)
+		wantText    string
+		wantPayload map[string]interface{}
+	}{
+		{
+			name: "button with payload",
+			toMessage: func() (*GenericBotMessage, error) {
+				return (*template.Buttons)[0].Action.ToMessage("channel-1", "message-1")
+			},
+			wantText: "Show by region",
+			wantPayload: map[string]interface{}{
+				"eventName": "DRILL_DOWN",
+				"payload":   map[string]interface{}{"dimension": "region", "filters": map[string]interface{}{"quarter": "2026-Q3"}},
+			},
+		},
+		{
+			name: "button with null payload",
+			toMessage: func() (*GenericBotMessage, error) {
+				return (*template.Buttons)[1].Action.ToMessage("channel-1", "message-1")
+			},
+			wantPayload: map[string]interface{}{"eventName": "REFRESH"},
+		},
+		{
+			name: "quick reply",
+			toMessage: func() (*GenericBotMessage, error) {
+				return template.QuickReplies[0].ToMessage("channel-1", "message-1")
+			},
+			wantText: "Export",
+			wantPayload: map[string]interface{}{
+				"eventName": "EXPORT_CSV",
+				"payload":   map[string]interface{}{"format": "csv"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := tt.toMessage()
+			if err != nil {
+				t.Fatalf("ToMessage: %v", err)
+			}
+			if msg.CustomChannelId != "channel-1" || msg.CustomMessageId != "message-1" {
+				t.Errorf("expected channel-1/message-1, got %s/%s", msg.CustomChannelId, msg.CustomMessageId)
+			}
+			if msg.Action != PostBackActionNone {
+				t.Errorf("expected action %s, got %s", PostBackActionNone, msg.Action)
+			}
+			if msg.Text != tt.wantText {
+				t.Errorf("expected text %q, got %q", tt.wantText, msg.Text)
+			}
+			if !reflect.DeepEqual(msg.Payload, tt.wantPayload) {
+				t.Errorf("expected payload %v, got %v", tt.wantPayload, msg.Payload)
+			}
+		})
+	}
+}
+
+func TestEmitActionRequiresEventName(t *testing.T) {
+	var action MessageTemplateAction
+	if err := json.Unmarshal([]byte(`{"type":"EMIT","text":"Go","uri":null,"payload":{"a":1}}`), &action); err != nil {
+		t.Fatalf("failed to decode action: %v", err)
+	}
+	if err := action.Validate(); err == nil {
+		t.Fatal("expected an EMIT action without eventName to be invalid")
+	}
+	if _, err := action.ToMessage("channel-1", "message-1"); err == nil {
+		t.Fatal("expected ToMessage to reject an EMIT action without eventName")
+	}
+}
+
+func TestNewEmitActionRoundTrip(t *testing.T) {
+	action := NewEmitAction("DRILL_DOWN", map[string]interface{}{"dimension": "region"})
+	data, err := json.Marshal(action)
+	if err != nil {
+		t.Fatalf("failed to encode action: %v", err)
+	}
+
+	var decoded MessageTemplateAction
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode action: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, action) {
+		t.Fatalf("expected %+v after a round trip, got %+v", action, decoded)
+	}
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChatRole identifies who produced a chat message.
+type ChatRole string
+
+const (
+	ChatRoleUser ChatRole = "user"
+	// ChatRoleAssistant is also the Role of chat messages converted from bot
+	// replies.
+	ChatRoleAssistant ChatRole = "assistant"
+	ChatRoleTool      ChatRole = "tool"
+)
+
+// ChatAttachmentType identifies what a ChatAttachment carries.
+type ChatAttachmentType string
+
+const (
+	ChatAttachmentTypeImage    ChatAttachmentType = "image"
+	ChatAttachmentTypeVideo    ChatAttachmentType = "video"
+	ChatAttachmentTypeAudio    ChatAttachmentType = "audio"
+	ChatAttachmentTypeLocation ChatAttachmentType = "location"
+	ChatAttachmentTypeChart    ChatAttachmentType = "chart"
+	ChatAttachmentTypeTable    ChatAttachmentType = "table"
+	ChatAttachmentTypeLink     ChatAttachmentType = "link"
+)
+
+// ChatMessage is a provider-agnostic chat message, the shape most chat
+// frameworks expect.
+type ChatMessage struct {
+	Role        ChatRole         `json:"role"`
+	Content     string           `json:"content"`
+	Attachments []ChatAttachment `json:"attachments,omitempty"`
+}
+
+// ChatAttachment is media or structured data that accompanies a ChatMessage.
+type ChatAttachment struct {
+	Type       ChatAttachmentType `json:"type"`
+	Url        string             `json:"url,omitempty"`
+	PreviewUrl string             `json:"previewUrl,omitempty"`
+	Title      string             `json:"title,omitempty"`
+	// Data is the payload of attachments that are not media: the
+	// coordinates of a location as [latitude, longitude], the data of a
+	// chart or the *MessageTemplateTable of a table.
+	Data interface{} `json:"data,omitempty"`
+}
+
+// ToChatMessages converts reply into provider-agnostic chat messages, one per
+// message in OrderedItems order. Debug messages are left out. Templates are
+// flattened: their text, titles and button labels go into Content, one per
+// line, and media, locations, charts, tables and references become
+// Attachments.
+func ToChatMessages(reply *GenericBotReply) []ChatMessage {
+	if reply == nil {
+		return nil
+	}
+
+	var out []ChatMessage
+	for _, item := range reply.OrderedItems() {
+		if item.Message.IsDebug {
+			continue
+		}
+		msg := ChatMessage{Role: ChatRoleAssistant, Content: item.Message.Text}
+		if item.Template != nil {
+			flattenTemplate(&msg, item.Template)
+		}
+		out = append(out, msg)
+	}
+	return out
+}
+
+func flattenTemplate(msg *ChatMessage, t *MessageTemplate) {
+	var lines []string
+	if msg.Content != "" {
+		lines = append(lines, msg.Content)
+	}
+	addLine := func(s *string) {
+		if s != nil && *s != "" && *s != msg.Content {
+			lines = append(lines, *s)
+		}
+	}
+
+	switch t.Type {
+	case MessageTemplateTypeImage:
+		msg.Attachments = append(msg.Attachments, ChatAttachment{
+			Type:       ChatAttachmentTypeImage,
+			Url:        deref(t.OriginalContentUrl),
+			PreviewUrl: deref(t.PreviewImageUrl),
+		})
+	case MessageTemplateTypeVideo, MessageTemplateTypeAudio:
+		kind := ChatAttachmentTypeVideo
+		if t.Type == MessageTemplateTypeAudio {
+			kind = ChatAttachmentTypeAudio
+		}
+		msg.Attachments = append(msg.Attachments, ChatAttachment{
+			Type:       kind,
+			Url:        deref(t.OriginalContentUrl),
+			PreviewUrl: deref(t.PreviewImageUrl),
+		})
+	case MessageTemplateTypeLocation:
+		addLine(t.Title)
+		attachment := ChatAttachment{Type: ChatAttachmentTypeLocation, Title: deref(t.Title)}
+		if t.Latitude != nil && t.Longitude != nil {
+			attachment.Data = []float64{*t.Latitude, *t.Longitude}
+		}
+		msg.Attachments = append(msg.Attachments, attachment)
+	case MessageTemplateTypeCarousel:
+		if t.Columns != nil {
+			for _, col := range *t.Columns {
+				if text := strings.TrimSpace(col.Title + "\n" + col.Text); text != "" {
+					lines = append(lines, text)
+				}
+				if col.ThumbnailImageUrl != nil {
+					msg.Attachments = append(msg.Attachments, ChatAttachment{
+						Type:  ChatAttachmentTypeImage,
+						Url:   *col.ThumbnailImageUrl,
+						Title: col.Title,
+					})
+				}
+				lines = append(lines, buttonLines(col.Buttons)...)
+			}
+		}
+	case MessageTemplateTypeChart:
+		addLine(t.Title)
+		attachment := ChatAttachment{Type: ChatAttachmentTypeChart, Title: deref(t.Title)}
+		if t.Data != nil {
+			attachment.Data = *t.Data
+		}
+		msg.Attachments = append(msg.Attachments, attachment)
+	case MessageTemplateTypeTable:
+		addLine(t.Title)
+		if t.Table != nil {
+			msg.Attachments = append(msg.Attachments, ChatAttachment{Type: ChatAttachmentTypeTable, Title: deref(t.Title), Data: t.Table})
+		}
+	default:
+		addLine(t.Title)
+	}
+
+	addLine(t.Text)
+	if t.Buttons != nil {
+		lines = append(lines, buttonLines(*t.Buttons)...)
+	}
+	if t.ThumbnailImageUrl != nil && t.Type == MessageTemplateTypeButton {
+		msg.Attachments = append(msg.Attachments, ChatAttachment{Type: ChatAttachmentTypeImage, Url: *t.ThumbnailImageUrl})
+	}
+	for _, ref := range t.References {
+		msg.Attachments = append(msg.Attachments, ChatAttachment{Type: ChatAttachmentTypeLink, Url: ref.Uri, Title: ref.Title})
+	}
+
+	msg.Content = strings.Join(lines, "\n")
+}
+
+// buttonLines renders buttons as list items, with the target of URI buttons.
+func buttonLines(buttons []MessageTemplateButton) []string {
+	lines := make([]string, 0, len(buttons))
+	for _, b := range buttons {
+		if b.Action.Type == MessageTemplateActionTypeUri && b.Action.Uri != nil {
+			lines = append(lines, fmt.Sprintf("- %s (%s)", b.Label, *b.Action.Uri))
+			continue
+		}
+		lines = append(lines, "- "+b.Label)
+	}
+	return lines
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -9,6 +9,7 @@ const (
 	SseEventTypeRunError         SseEventType = "asgard.run.error"
 	SseEventTypeProcessStart     SseEventType = "asgard.process.start"
 	SseEventTypeProcessComplete  SseEventType = "asgard.process.complete"
+	SseEventTypeProcessLog       SseEventType = "asgard.process.log"
 	SseEventTypeMessageStart     SseEventType = "asgard.message.start"
 	SseEventTypeMessageDelta     SseEventType = "asgard.message.delta"
 	SseEventTypeMessageComplete  SseEventType = "asgard.message.complete"
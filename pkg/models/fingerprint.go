@@ -0,0 +1,56 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Fingerprint returns a sha256 hex digest of the message's channel, text,
+// action, sorted blob IDs, payload and context, leaving out CustomMessageId.
+// A nil payload or context hashes like an empty one.
+func (m *GenericBotMessage) Fingerprint() string {
+	blobIDs := append([]string{}, m.BlobIds...)
+	sort.Strings(blobIDs)
+
+	canonical := struct {
+		CustomChannelId string                 `json:"customChannelId"`
+		Text            string                 `json:"text"`
+		Action          PostBackAction         `json:"action"`
+		BlobIds         []string               `json:"blobIds"`
+		Payload         map[string]interface{} `json:"payload"`
+		Context         map[string]interface{} `json:"context,omitempty"`
+		Unencodable     string                 `json:"unencodable,omitempty"`
+	}{
+		CustomChannelId: m.CustomChannelId,
+		Text:            m.Text,
+		Action:          m.Action,
+		BlobIds:         blobIDs,
+		Payload:         nilIfEmpty(m.Payload),
+		Context:         nilIfEmpty(m.Context),
+	}
+
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		// Payload or context values that cannot be marshalled would also
+		// fail to send; hash the error and their formatted values instead,
+		// so such messages still differ by content.
+		canonical.Unencodable = fmt.Sprintf("%v: %v %v", err, canonical.Payload, canonical.Context)
+		canonical.Payload = nil
+		canonical.Context = nil
+		data, _ = json.Marshal(canonical)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// nilIfEmpty returns nil for an empty map, so nil and empty maps hash alike.
+func nilIfEmpty(m map[string]interface{}) map[string]interface{} {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,52 @@
+package models
+
+import "testing"
+
+func TestFingerprint(t *testing.T) {
+	base := GenericBotMessage{CustomChannelId: "ch", Text: "hi"}
+	with := func(change func(m *GenericBotMessage)) *GenericBotMessage {
+		m := base
+		change(&m)
+		return &m
+	}
+
+	same := []struct {
+		name string
+		a, b *GenericBotMessage
+	}{
+		{"message ID", with(func(m *GenericBotMessage) { m.CustomMessageId = "a" }), with(func(m *GenericBotMessage) { m.CustomMessageId = "b" })},
+		{"nil and empty payload", with(func(m *GenericBotMessage) { m.Payload = nil }), with(func(m *GenericBotMessage) { m.Payload = map[string]interface{}{} })},
+		{"nil and empty context", with(func(m *GenericBotMessage) { m.Context = nil }), with(func(m *GenericBotMessage) { m.Context = map[string]interface{}{} })},
+		{"blob order", with(func(m *GenericBotMessage) { m.BlobIds = []string{"a", "b"} }), with(func(m *GenericBotMessage) { m.BlobIds = []string{"b", "a"} })},
+	}
+	for _, tc := range same {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.a.Fingerprint() != tc.b.Fingerprint() {
+				t.Fatal("expected the same fingerprint")
+			}
+		})
+	}
+
+	different := []struct {
+		name string
+		a, b *GenericBotMessage
+	}{
+		{"text", with(func(m *GenericBotMessage) { m.Text = "a" }), with(func(m *GenericBotMessage) { m.Text = "b" })},
+		{"payload", with(func(m *GenericBotMessage) { m.Payload = map[string]interface{}{"k": 1} }), with(func(m *GenericBotMessage) { m.Payload = map[string]interface{}{"k": 2} })},
+		{"unencodable payload", with(func(m *GenericBotMessage) {
+			m.Payload = map[string]interface{}{"f": func() {}, "k": 1}
+		}), with(func(m *GenericBotMessage) {
+			m.Payload = map[string]interface{}{"f": func() {}, "k": 2}
+		})},
+		{"unencodable and encodable payload", with(func(m *GenericBotMessage) {
+			m.Payload = map[string]interface{}{"f": func() {}}
+		}), with(func(m *GenericBotMessage) { m.Payload = nil })},
+	}
+	for _, tc := range different {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.a.Fingerprint() == tc.b.Fingerprint() {
+				t.Fatal("expected different fingerprints")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownFence       = regexp.MustCompile("(?ms)^[ \t]*```[ \t]*([^\\s`]*)[^\\n]*\\n(.*?)^[ \t]*```[ \t]*$")
+	markdownLink        = regexp.MustCompile(`!?\[([^\]\n]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	markdownHeading     = regexp.MustCompile(`(?m)^#{1,6}[ \t]+\S`)
+	markdownList        = regexp.MustCompile(`(?m)^[ \t]*(?:[-*+]|\d+\.)[ \t]+\S`)
+	markdownQuote       = regexp.MustCompile(`(?m)^[ \t]*>[ \t]?\S`)
+	markdownEmphasis    = regexp.MustCompile(`\*\*[^*\n]+\*\*|__[^_\n]+__`)
+	markdownInlineCode  = regexp.MustCompile("`[^`\n]+`")
+	markdownTableHeader = regexp.MustCompile(`(?m)^[ \t]*\|?[ \t]*:?-{3,}:?[ \t]*(?:\|[ \t]*:?-{3,}:?[ \t]*)+\|?[ \t]*$`)
+)
+
+// MarkdownLink is a link or image found in markdown text.
+type MarkdownLink struct {
+	Text    string
+	URL     string
+	IsImage bool
+}
+
+// MarkdownCodeBlock is a fenced code block found in markdown text.
+type MarkdownCodeBlock struct {
+	// Language is the info string after the opening fence, if any.
+	Language string
+	Code     string
+}
+
+// MarkdownInfo is a lightweight summary of the markdown in a message text,
+// meant for building previews. It does not render anything.
+type MarkdownInfo struct {
+	// IsMarkdown reports whether the text appears to use markdown syntax.
+	IsMarkdown bool
+	Links      []MarkdownLink
+	CodeBlocks []MarkdownCodeBlock
+}
+
+// ParseMarkdown detects markdown syntax in text and extracts its links and
+// fenced code blocks. Detection is heuristic: headings, lists, block quotes,
+// tables, emphasis, inline code, links and code fences all count. Links inside
+// code blocks are not reported.
+func ParseMarkdown(text string) MarkdownInfo {
+	var info MarkdownInfo
+
+	for _, m := range markdownFence.FindAllStringSubmatch(text, -1) {
+		info.CodeBlocks = append(info.CodeBlocks, MarkdownCodeBlock{
+			Language: m[1],
+			Code:     strings.TrimSuffix(m[2], "\n"),
+		})
+	}
+	prose := markdownFence.ReplaceAllString(text, "")
+
+	for _, m := range markdownLink.FindAllStringSubmatch(prose, -1) {
+		info.Links = append(info.Links, MarkdownLink{
+			Text:    m[1],
+			URL:     m[2],
+			IsImage: strings.HasPrefix(m[0], "!"),
+		})
+	}
+
+	info.IsMarkdown = len(info.CodeBlocks) > 0 || len(info.Links) > 0 ||
+		markdownHeading.MatchString(prose) ||
+		markdownList.MatchString(prose) ||
+		markdownQuote.MatchString(prose) ||
+		markdownEmphasis.MatchString(prose) ||
+		markdownInlineCode.MatchString(prose) ||
+		markdownTableHeader.MatchString(prose)
+
+	return info
+}
+
+// Markdown summarizes the markdown in the message text. See ParseMarkdown.
+func (m *BufferedMessage) Markdown() MarkdownInfo {
+	return ParseMarkdown(m.Text)
+}
@@ -0,0 +1,36 @@
+package models
+
+import "fmt"
+
+// NewVideoTemplate creates a VIDEO template playing originalContentUrl with
+// previewImageUrl as its poster. duration is the length in milliseconds; zero
+// leaves it unset. Missing URLs and negative durations are rejected.
+func NewVideoTemplate(originalContentUrl, previewImageUrl string, duration int64) (*MessageTemplate, error) {
+	t := &MessageTemplate{
+		Type:               MessageTemplateTypeVideo,
+		OriginalContentUrl: &originalContentUrl,
+		PreviewImageUrl:    &previewImageUrl,
+	}
+	return newMediaTemplate(t, duration)
+}
+
+// NewAudioTemplate creates an AUDIO template playing originalContentUrl.
+// duration is the length in milliseconds; zero leaves it unset. A missing URL
+// and negative durations are rejected.
+func NewAudioTemplate(originalContentUrl string, duration int64) (*MessageTemplate, error) {
+	t := &MessageTemplate{
+		Type:               MessageTemplateTypeAudio,
+		OriginalContentUrl: &originalContentUrl,
+	}
+	return newMediaTemplate(t, duration)
+}
+
+func newMediaTemplate(t *MessageTemplate, duration int64) (*MessageTemplate, error) {
+	if duration != 0 {
+		t.Duration = &duration
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", t.Type, err)
+	}
+	return t, nil
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // GenericBotMessage represents a message sent from client to the Edge Server
 type GenericBotMessage struct {
 	CustomChannelId string                 `json:"customChannelId"`
@@ -8,6 +10,22 @@ type GenericBotMessage struct {
 	Action          PostBackAction         `json:"action"`
 	BlobIds         []string               `json:"blobIds,omitempty"`
 	Payload         map[string]interface{} `json:"payload,omitempty"`
+	// Context carries conversation-level context for the bot, such as a
+	// system prompt or user profile. Send it with the first message of a
+	// conversation. The SDK never caches or resends it: after a
+	// RESET_CHANNEL the channel starts over, so include it again on the
+	// first message that follows the reset if the bot still needs it.
+	Context map[string]interface{} `json:"context,omitempty"`
+	// Timestamp, when set, is the time the message was originally sent, for
+	// replaying or backfilling conversations. It is sent as RFC 3339; without
+	// it the server records the time of receipt.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// WithTimestamp sets the message's Timestamp and returns the message.
+func (m *GenericBotMessage) WithTimestamp(t time.Time) *GenericBotMessage {
+	m.Timestamp = &t
+	return m
 }
 
 // PostBackAction defines the action type for a message
@@ -0,0 +1,73 @@
+package models
+
+// ProcessTask is a typed view over the task payloads attached to process
+// events. Only the fields shared by every task are modelled; Raw keeps the
+// original decoded value so anything else the server sends is still reachable.
+type ProcessTask struct {
+	Name string      `json:"name"`
+	Kind string      `json:"kind"`
+	Raw  interface{} `json:"-"`
+}
+
+// parseProcessTask decodes a task payload into a ProcessTask. It reports false
+// when the payload is missing or is not a JSON object.
+func parseProcessTask(v *interface{}) (*ProcessTask, bool) {
+	if v == nil || *v == nil {
+		return nil, false
+	}
+	fields, ok := (*v).(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	task := &ProcessTask{Raw: *v}
+	task.Name, _ = fields["name"].(string)
+	task.Kind, _ = fields["kind"].(string)
+	return task, true
+}
+
+// ParsedTask returns the typed view of Task.
+func (f *GenericBotSseEventFactProcessStart) ParsedTask() (*ProcessTask, bool) {
+	return parseProcessTask(f.Task)
+}
+
+// TaskName returns the task name, if the server sent one.
+func (f *GenericBotSseEventFactProcessStart) TaskName() (string, bool) {
+	task, ok := f.ParsedTask()
+	if !ok || task.Name == "" {
+		return "", false
+	}
+	return task.Name, true
+}
+
+// TaskKind returns the task kind, if the server sent one.
+func (f *GenericBotSseEventFactProcessStart) TaskKind() (string, bool) {
+	task, ok := f.ParsedTask()
+	if !ok || task.Kind == "" {
+		return "", false
+	}
+	return task.Kind, true
+}
+
+// ParsedTaskResult returns the typed view of TaskResult.
+func (f *GenericBotSseEventFactProcessComplete) ParsedTaskResult() (*ProcessTask, bool) {
+	return parseProcessTask(f.TaskResult)
+}
+
+// TaskName returns the task name carried by the result, if the server sent one.
+func (f *GenericBotSseEventFactProcessComplete) TaskName() (string, bool) {
+	task, ok := f.ParsedTaskResult()
+	if !ok || task.Name == "" {
+		return "", false
+	}
+	return task.Name, true
+}
+
+// TaskKind returns the task kind carried by the result, if the server sent one.
+func (f *GenericBotSseEventFactProcessComplete) TaskKind() (string, bool) {
+	task, ok := f.ParsedTaskResult()
+	if !ok || task.Kind == "" {
+		return "", false
+	}
+	return task.Kind, true
+}
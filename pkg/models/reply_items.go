@@ -0,0 +1,64 @@
+package models
+
+import "sort"
+
+// ReplyItemKind identifies what a ReplyItem carries.
+type ReplyItemKind string
+
+const (
+	ReplyItemKindText     ReplyItemKind = "TEXT"
+	ReplyItemKindTemplate ReplyItemKind = "TEMPLATE"
+)
+
+// ReplyItem is one renderable part of a reply: either a text message or a
+// template message.
+type ReplyItem struct {
+	Kind ReplyItemKind
+	// Text is set for TEXT items.
+	Text string
+	// Template is set for TEMPLATE items.
+	Template *MessageTemplate
+	// Message is the message the item was taken from.
+	Message *BufferedMessage
+}
+
+// OrderedItems returns the reply's messages as text and template items in
+// Idx order, so text and templates can be rendered interleaved as the bot
+// intended. Messages without an Idx keep their relative position after the
+// indexed ones. Messages with neither text nor a template are skipped.
+func (r *GenericBotReply) OrderedItems() []ReplyItem {
+	msgs := make([]*BufferedMessage, 0, len(r.Messages))
+	for i := range r.Messages {
+		msgs = append(msgs, &r.Messages[i])
+	}
+	sort.SliceStable(msgs, func(i, j int) bool {
+		a, b := msgs[i].Idx, msgs[j].Idx
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+
+	items := make([]ReplyItem, 0, len(msgs))
+	for _, msg := range msgs {
+		switch {
+		case msg.Template != nil:
+			items = append(items, ReplyItem{Kind: ReplyItemKindTemplate, Template: msg.Template, Message: msg})
+		case msg.Text != "":
+			items = append(items, ReplyItem{Kind: ReplyItemKindText, Text: msg.Text, Message: msg})
+		}
+	}
+	return items
+}
+
+// SortMessagesByIdx sorts messages in place into Idx order. Messages without
+// an Idx keep their relative position after the indexed ones.
+func SortMessagesByIdx(messages []BufferedMessage) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		a, b := messages[i].Idx, messages[j].Idx
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+}
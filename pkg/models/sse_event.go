@@ -14,14 +14,15 @@ type GenericBotSseEvent struct {
 // GenericBotSseEventFact contains the polymorphic event data
 // Only one field will be non-nil depending on the EventType
 type GenericBotSseEventFact struct {
-	RunInit          *GenericBotSseEventFactRunInit          `json:"runInit"`
-	RunDone          *GenericBotSseEventFactRunDone          `json:"runDone"`
-	RunError         *GenericBotSseEventFactRunError         `json:"runError"`
-	ProcessStart     *GenericBotSseEventFactProcessStart     `json:"processStart"`
-	ProcessComplete  *GenericBotSseEventFactProcessComplete  `json:"processComplete"`
-	MessageStart     *GenericBotSseEventFactMessage          `json:"messageStart"`
-	MessageDelta     *GenericBotSseEventFactMessage          `json:"messageDelta"`
-	MessageComplete  *GenericBotSseEventFactMessage          `json:"messageComplete"`
+	RunInit              *GenericBotSseEventFactRunInit              `json:"runInit"`
+	RunDone              *GenericBotSseEventFactRunDone              `json:"runDone"`
+	RunError             *GenericBotSseEventFactRunError             `json:"runError"`
+	ProcessStart         *GenericBotSseEventFactProcessStart         `json:"processStart"`
+	ProcessComplete      *GenericBotSseEventFactProcessComplete      `json:"processComplete"`
+	ProcessLog           *GenericBotSseEventFactProcessLog           `json:"processLog"`
+	MessageStart         *GenericBotSseEventFactMessage              `json:"messageStart"`
+	MessageDelta         *GenericBotSseEventFactMessage              `json:"messageDelta"`
+	MessageComplete      *GenericBotSseEventFactMessage              `json:"messageComplete"`
 	ToolCallStart        *GenericBotSseEventFactToolCallStart        `json:"toolCallStart"`
 	ToolCallComplete     *GenericBotSseEventFactToolCallComplete     `json:"toolCallComplete"`
 	CompletionModelUsage *GenericBotSseEventFactCompletionModelUsage `json:"completionModelUsage"`
@@ -50,6 +51,13 @@ type GenericBotSseEventFactProcessComplete struct {
 	TaskResult *interface{} `json:"taskResult"`
 }
 
+// GenericBotSseEventFactProcessLog is emitted for each log line a process
+// writes while it runs
+type GenericBotSseEventFactProcessLog struct {
+	ProcessId string `json:"processId"`
+	Line      string `json:"line"`
+}
+
 // GenericBotSseEventFactMessage is emitted for message-related events
 type GenericBotSseEventFactMessage struct {
 	Message BufferedMessage `json:"message"`
@@ -92,3 +100,23 @@ type GenericBotSseEventWrapper struct {
 	Event           *GenericBotSseEvent `json:"event"`
 	ConnectionError error               `json:"connectionError,omitempty"`
 }
+
+// References returns the template references carried by a message event's
+// start, delta or complete fact, or nil for other events.
+func (e *GenericBotSseEvent) References() []MessageTemplateReference {
+	var fact *GenericBotSseEventFactMessage
+	switch {
+	case e.Fact.MessageComplete != nil:
+		fact = e.Fact.MessageComplete
+	case e.Fact.MessageDelta != nil:
+		fact = e.Fact.MessageDelta
+	case e.Fact.MessageStart != nil:
+		fact = e.Fact.MessageStart
+	default:
+		return nil
+	}
+	if fact.Message.Template == nil {
+		return nil
+	}
+	return fact.Message.Template.References
+}
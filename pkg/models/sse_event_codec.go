@@ -0,0 +1,114 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EncodeEvent returns the compact JSON encoding of an event, in the shape
+// EdgeServer sends it. DecodeEvent restores the typed event losslessly.
+func EncodeEvent(event *GenericBotSseEvent) ([]byte, error) {
+	if event == nil {
+		return nil, fmt.Errorf("event cannot be nil")
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeEvent reconstructs an event encoded with EncodeEvent, or received
+// verbatim from EdgeServer.
+func DecodeEvent(data []byte) (*GenericBotSseEvent, error) {
+	var event GenericBotSseEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+	if event.EventType == "" {
+		return nil, fmt.Errorf("failed to decode event: missing eventType")
+	}
+	return &event, nil
+}
+
+// EventSummary is a flat, fixed-shape view of an event for log and analytics
+// pipelines. Fields that do not apply to the event type are left empty.
+type EventSummary struct {
+	EventType           SseEventType        `json:"eventType"`
+	RequestId           string              `json:"requestId"`
+	EventId             string              `json:"eventId"`
+	Namespace           string              `json:"namespace"`
+	BotProviderName     string              `json:"botProviderName"`
+	CustomChannelId     string              `json:"customChannelId"`
+	ProcessId           string              `json:"processId,omitempty"`
+	MessageId           string              `json:"messageId,omitempty"`
+	TextLength          int                 `json:"textLength,omitempty"`
+	TemplateType        MessageTemplateType `json:"templateType,omitempty"`
+	ToolsetName         string              `json:"toolsetName,omitempty"`
+	ToolName            string              `json:"toolName,omitempty"`
+	CallSeq             int                 `json:"callSeq,omitempty"`
+	CompletionModelName string              `json:"completionModelName,omitempty"`
+	InputTokens         int64               `json:"inputTokens,omitempty"`
+	OutputTokens        int64               `json:"outputTokens,omitempty"`
+	TotalTokens         int64               `json:"totalTokens,omitempty"`
+	ErrorCode           string              `json:"errorCode,omitempty"`
+	ErrorMessage        string              `json:"errorMessage,omitempty"`
+}
+
+// Summary derives the EventSummary of the event.
+func (e *GenericBotSseEvent) Summary() EventSummary {
+	s := EventSummary{
+		EventType:       e.EventType,
+		RequestId:       e.RequestId,
+		EventId:         e.EventId,
+		Namespace:       e.Namespace,
+		BotProviderName: e.BotProviderName,
+		CustomChannelId: e.CustomChannelId,
+	}
+
+	f := e.Fact
+	switch {
+	case f.RunError != nil:
+		s.ErrorCode = f.RunError.Error.Code
+		s.ErrorMessage = f.RunError.Error.Message
+		s.ProcessId = f.RunError.Error.Location.ProcessId
+	case f.ProcessStart != nil:
+		s.ProcessId = f.ProcessStart.ProcessId
+	case f.ProcessComplete != nil:
+		s.ProcessId = f.ProcessComplete.ProcessId
+	case f.ProcessLog != nil:
+		s.ProcessId = f.ProcessLog.ProcessId
+	case f.MessageStart != nil:
+		summarizeMessage(&s, &f.MessageStart.Message)
+	case f.MessageDelta != nil:
+		summarizeMessage(&s, &f.MessageDelta.Message)
+	case f.MessageComplete != nil:
+		summarizeMessage(&s, &f.MessageComplete.Message)
+	case f.ToolCallStart != nil:
+		s.ProcessId = f.ToolCallStart.ProcessId
+		s.CallSeq = f.ToolCallStart.CallSeq
+		s.ToolsetName = f.ToolCallStart.ToolCall.ToolsetName
+		s.ToolName = f.ToolCallStart.ToolCall.ToolName
+	case f.ToolCallComplete != nil:
+		s.ProcessId = f.ToolCallComplete.ProcessId
+		s.CallSeq = f.ToolCallComplete.CallSeq
+		s.ToolsetName = f.ToolCallComplete.ToolCall.ToolsetName
+		s.ToolName = f.ToolCallComplete.ToolCall.ToolName
+	case f.CompletionModelUsage != nil:
+		s.ProcessId = f.CompletionModelUsage.ProcessId
+		s.CompletionModelName = f.CompletionModelUsage.CompletionModelName
+		s.InputTokens = f.CompletionModelUsage.InputTokens
+		s.OutputTokens = f.CompletionModelUsage.OutputTokens
+		s.TotalTokens = f.CompletionModelUsage.TotalTokens
+	}
+
+	return s
+}
+
+func summarizeMessage(s *EventSummary, m *BufferedMessage) {
+	s.MessageId = m.MessageId
+	s.TextLength = len(m.Text)
+	if m.Template != nil {
+		s.TemplateType = m.Template.Type
+	}
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeEventRoundTrip(t *testing.T) {
+	var task interface{} = map[string]interface{}{"name": "summarize"}
+	var result interface{} = map[string]interface{}{"summary": "done"}
+	idx := 1
+	text := "hello"
+	message := BufferedMessage{
+		MessageId: "m1",
+		Text:      "hello",
+		Payload:   map[string]interface{}{"k": "v"},
+		Idx:       &idx,
+		Template:  &MessageTemplate{Type: MessageTemplateTypeText, Text: &text},
+	}
+	toolCall := ToolCall{ToolsetName: "search", ToolName: "web", Parameter: map[string]interface{}{"q": "go"}}
+
+	facts := map[SseEventType]GenericBotSseEventFact{
+		SseEventTypeRunInit: {RunInit: &GenericBotSseEventFactRunInit{}},
+		SseEventTypeRunDone: {RunDone: &GenericBotSseEventFactRunDone{}},
+		SseEventTypeRunError: {RunError: &GenericBotSseEventFactRunError{Error: ErrorDetail{
+			Message: "boom", Code: "E1", Inner: "inner", Location: ErrorLocation{Namespace: "default", ProcessId: "p1"},
+		}}},
+		SseEventTypeProcessStart:    {ProcessStart: &GenericBotSseEventFactProcessStart{ProcessId: "p1", Task: &task}},
+		SseEventTypeProcessComplete: {ProcessComplete: &GenericBotSseEventFactProcessComplete{ProcessId: "p1", TaskResult: &result}},
+		SseEventTypeProcessLog:      {ProcessLog: &GenericBotSseEventFactProcessLog{ProcessId: "p1", Line: "step 1"}},
+		SseEventTypeMessageStart:    {MessageStart: &GenericBotSseEventFactMessage{Message: message}},
+		SseEventTypeMessageDelta:    {MessageDelta: &GenericBotSseEventFactMessage{Message: message}},
+		SseEventTypeMessageComplete: {MessageComplete: &GenericBotSseEventFactMessage{Message: message}},
+		SseEventTypeToolCallStart:   {ToolCallStart: &GenericBotSseEventFactToolCallStart{ProcessId: "p1", CallSeq: 2, ToolCall: toolCall}},
+		SseEventTypeToolCallComplete: {ToolCallComplete: &GenericBotSseEventFactToolCallComplete{
+			ProcessId: "p1", CallSeq: 2, ToolCall: toolCall, ToolCallResult: []interface{}{"a", "b"},
+		}},
+		SseEventTypeCompletionModelUsage: {CompletionModelUsage: &GenericBotSseEventFactCompletionModelUsage{
+			ProcessId: "p1", CompletionModelName: "model", InputTokens: 10, OutputTokens: 5, TotalTokens: 15,
+		}},
+	}
+
+	for eventType, fact := range facts {
+		t.Run(string(eventType), func(t *testing.T) {
+			event := &GenericBotSseEvent{
+				EventType:       eventType,
+				RequestId:       "r1",
+				EventId:         "e1",
+				Namespace:       "default",
+				BotProviderName: "bot",
+				CustomChannelId: "ch",
+				Fact:            fact,
+			}
+
+			data, err := EncodeEvent(event)
+			if err != nil {
+				t.Fatalf("EncodeEvent: %v", err)
+			}
+			got, err := DecodeEvent(data)
+			if err != nil {
+				t.Fatalf("DecodeEvent: %v", err)
+			}
+			if !reflect.DeepEqual(got, event) {
+				t.Fatalf("round trip changed the event:\n got %+v\nwant %+v", got, event)
+			}
+
+			// Every fact is on the wire, nil ones as null, as EdgeServer
+			// sends them.
+			var wire struct {
+				Fact map[string]json.RawMessage `json:"fact"`
+			}
+			if err := json.Unmarshal(data, &wire); err != nil {
+				t.Fatalf("failed to decode encoded event: %v", err)
+			}
+			if n := reflect.TypeOf(fact).NumField(); len(wire.Fact) != n {
+				t.Fatalf("expected %d fact fields on the wire, got %d: %s", n, len(wire.Fact), data)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+// PageCount returns the number of pages of Data given Pagination.Size. A
+// table without pagination, or with a non-positive size, is a single page.
+func (t *MessageTemplateTable) PageCount() int {
+	size := t.pageSize()
+	if size == 0 || len(t.Data) == 0 {
+		return 1
+	}
+	return (len(t.Data) + size - 1) / size
+}
+
+// Page returns the rows of the zero-based page index, or false when index is
+// out of range. The returned slice shares storage with Data.
+func (t *MessageTemplateTable) Page(index int) ([]interface{}, bool) {
+	if index < 0 || index >= t.PageCount() {
+		return nil, false
+	}
+
+	size := t.pageSize()
+	if size == 0 {
+		return t.Data, true
+	}
+
+	start := index * size
+	end := start + size
+	if end > len(t.Data) {
+		end = len(t.Data)
+	}
+	return t.Data[start:end], true
+}
+
+func (t *MessageTemplateTable) pageSize() int {
+	if t.Pagination == nil || t.Pagination.Size <= 0 {
+		return 0
+	}
+	return t.Pagination.Size
+}
@@ -0,0 +1,201 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cellLocale describes how a locale renders dates and amounts.
+type cellLocale struct {
+	dateLayout     string
+	timeLayout     string
+	currencySymbol string
+	symbolAfter    bool
+	decimals       int
+	groupSep       string
+	decimalSep     string
+}
+
+// cellLocales lists the locales FormatCell knows about. Unlisted locales fall
+// back to the language alone, then to ISO dates and plain amounts.
+var cellLocales = map[string]cellLocale{
+	"en-US": {dateLayout: "01/02/2006", timeLayout: "3:04 PM", currencySymbol: "$", decimals: 2, groupSep: ",", decimalSep: "."},
+	"en-GB": {dateLayout: "02/01/2006", timeLayout: "15:04", currencySymbol: "£", decimals: 2, groupSep: ",", decimalSep: "."},
+	"en":    {dateLayout: "01/02/2006", timeLayout: "3:04 PM", currencySymbol: "$", decimals: 2, groupSep: ",", decimalSep: "."},
+	"zh-TW": {dateLayout: "2006/01/02", timeLayout: "15:04", currencySymbol: "NT$", decimals: 0, groupSep: ",", decimalSep: "."},
+	"zh-CN": {dateLayout: "2006/01/02", timeLayout: "15:04", currencySymbol: "¥", decimals: 2, groupSep: ",", decimalSep: "."},
+	"zh":    {dateLayout: "2006/01/02", timeLayout: "15:04", currencySymbol: "¥", decimals: 2, groupSep: ",", decimalSep: "."},
+	"ja-JP": {dateLayout: "2006/01/02", timeLayout: "15:04", currencySymbol: "¥", decimals: 0, groupSep: ",", decimalSep: "."},
+	"ja":    {dateLayout: "2006/01/02", timeLayout: "15:04", currencySymbol: "¥", decimals: 0, groupSep: ",", decimalSep: "."},
+	"de-DE": {dateLayout: "02.01.2006", timeLayout: "15:04", currencySymbol: "€", symbolAfter: true, decimals: 2, groupSep: ".", decimalSep: ","},
+	"de":    {dateLayout: "02.01.2006", timeLayout: "15:04", currencySymbol: "€", symbolAfter: true, decimals: 2, groupSep: ".", decimalSep: ","},
+	"fr-FR": {dateLayout: "02/01/2006", timeLayout: "15:04", currencySymbol: "€", symbolAfter: true, decimals: 2, groupSep: " ", decimalSep: ","},
+	"fr":    {dateLayout: "02/01/2006", timeLayout: "15:04", currencySymbol: "€", symbolAfter: true, decimals: 2, groupSep: " ", decimalSep: ","},
+}
+
+var defaultCellLocale = cellLocale{dateLayout: "2006-01-02", timeLayout: "15:04", decimals: 2, groupSep: ",", decimalSep: "."}
+
+// cellDateLayouts are the string layouts FormatCell parses date values with.
+var cellDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// FormatCell renders a table cell value according to its column format and a
+// BCP 47 locale such as "en-US" or "zh-TW". Dates accept RFC 3339 and
+// "2006-01-02[ 15:04[:05]]" strings, time.Time values and Unix timestamps in
+// seconds or milliseconds. Amounts accept numbers and numeric strings. A nil
+// value renders as an empty string, and a nil format renders the value as-is.
+func FormatCell(value interface{}, format *MessageTemplateTableColumnFormat, locale string) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	if format == nil {
+		return plainCell(value), nil
+	}
+
+	loc := lookupCellLocale(locale)
+	switch *format {
+	case MessageTemplateTableColumnFormatDate, MessageTemplateTableColumnFormatDateTime:
+		t, err := cellTime(value)
+		if err != nil {
+			return "", err
+		}
+		if *format == MessageTemplateTableColumnFormatDate {
+			return t.Format(loc.dateLayout), nil
+		}
+		return t.Format(loc.dateLayout + " " + loc.timeLayout), nil
+	case MessageTemplateTableColumnFormatCurrency:
+		amount, err := cellNumber(value)
+		if err != nil {
+			return "", err
+		}
+		return loc.formatCurrency(amount), nil
+	default:
+		return "", fmt.Errorf("unknown column format %q", *format)
+	}
+}
+
+func lookupCellLocale(locale string) cellLocale {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if loc, ok := cellLocales[locale]; ok {
+		return loc
+	}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		if loc, ok := cellLocales[lang]; ok {
+			return loc
+		}
+	}
+	return defaultCellLocale
+}
+
+func plainCell(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func cellTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, fmt.Errorf("nil time value")
+		}
+		return *v, nil
+	case string:
+		for _, layout := range cellDateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return unixCellTime(n), nil
+		}
+		return time.Time{}, fmt.Errorf("cannot parse %q as a date", v)
+	default:
+		n, err := cellNumber(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot use %T as a date", value)
+		}
+		return unixCellTime(n), nil
+	}
+}
+
+// unixCellTime treats values past the year 2286 in seconds as milliseconds.
+func unixCellTime(n float64) time.Time {
+	if math.Abs(n) >= 1e10 {
+		return time.UnixMilli(int64(n)).UTC()
+	}
+	return time.Unix(int64(n), 0).UTC()
+}
+
+func cellNumber(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse %q as a number", v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("cannot use %T as a number", value)
+	}
+}
+
+func (l cellLocale) formatCurrency(amount float64) string {
+	negative := amount < 0
+	digits := strconv.FormatFloat(math.Abs(amount), 'f', l.decimals, 64)
+
+	whole, frac, _ := strings.Cut(digits, ".")
+	var b strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.groupSep)
+		}
+		b.WriteRune(r)
+	}
+	number := b.String()
+	if frac != "" {
+		number += l.decimalSep + frac
+	}
+
+	switch {
+	case l.currencySymbol == "":
+	case l.symbolAfter:
+		number = number + " " + l.currencySymbol
+	default:
+		number = l.currencySymbol + number
+	}
+	if negative {
+		number = "-" + number
+	}
+	return number
+}
@@ -29,7 +29,9 @@ type MessageTemplate struct {
 
 // QuickReply represents a quick reply option
 type QuickReply struct {
-	Text string `json:"text"`
+	Text    string                 `json:"text"`
+	Payload interface{}            `json:"payload,omitempty"`
+	Action  *MessageTemplateAction `json:"action,omitempty"`
 }
 
 // MessageTemplateButton represents a button in a message template
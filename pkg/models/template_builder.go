@@ -0,0 +1,111 @@
+package models
+
+import "fmt"
+
+// TemplateBuilder assembles a MessageTemplate without taking the address of
+// every optional field by hand. Build validates the result.
+type TemplateBuilder struct {
+	template MessageTemplate
+}
+
+// NewTemplateBuilder starts a template of the given type.
+func NewTemplateBuilder(templateType MessageTemplateType) *TemplateBuilder {
+	return &TemplateBuilder{template: MessageTemplate{Type: templateType}}
+}
+
+// NewTextTemplate starts a TEXT template showing text.
+func NewTextTemplate(text string) *TemplateBuilder {
+	return NewTemplateBuilder(MessageTemplateTypeText).Text(text)
+}
+
+// NewButtonTemplate starts a BUTTON template showing text above its buttons.
+func NewButtonTemplate(text string) *TemplateBuilder {
+	return NewTemplateBuilder(MessageTemplateTypeButton).Text(text)
+}
+
+// NewCarouselTemplate starts an empty CAROUSEL template.
+func NewCarouselTemplate() *TemplateBuilder {
+	return NewTemplateBuilder(MessageTemplateTypeCarousel)
+}
+
+// Text sets the template text.
+func (b *TemplateBuilder) Text(text string) *TemplateBuilder {
+	b.template.Text = &text
+	return b
+}
+
+// Title sets the template title.
+func (b *TemplateBuilder) Title(title string) *TemplateBuilder {
+	b.template.Title = &title
+	return b
+}
+
+// Thumbnail sets the thumbnail image shown with a BUTTON template.
+func (b *TemplateBuilder) Thumbnail(url string) *TemplateBuilder {
+	b.template.ThumbnailImageUrl = &url
+	return b
+}
+
+// Image sets how the thumbnail image is laid out.
+func (b *TemplateBuilder) Image(aspectRatio ImageAspectRatio, size ImageSize) *TemplateBuilder {
+	b.template.ImageAspectRatio = &aspectRatio
+	b.template.ImageSize = &size
+	return b
+}
+
+// DefaultAction sets the action run when the template body is tapped.
+func (b *TemplateBuilder) DefaultAction(action MessageTemplateAction) *TemplateBuilder {
+	b.template.DefaultAction = &action
+	return b
+}
+
+// AddButton appends a button.
+func (b *TemplateBuilder) AddButton(label string, action MessageTemplateAction) *TemplateBuilder {
+	if b.template.Buttons == nil {
+		b.template.Buttons = &[]MessageTemplateButton{}
+	}
+	*b.template.Buttons = append(*b.template.Buttons, MessageTemplateButton{Label: label, Action: action})
+	return b
+}
+
+// AddColumn appends a carousel column.
+func (b *TemplateBuilder) AddColumn(column MessageTemplateColumn) *TemplateBuilder {
+	if b.template.Columns == nil {
+		b.template.Columns = &[]MessageTemplateColumn{}
+	}
+	*b.template.Columns = append(*b.template.Columns, column)
+	return b
+}
+
+// AddQuickReply appends a quick reply.
+func (b *TemplateBuilder) AddQuickReply(reply QuickReply) *TemplateBuilder {
+	b.template.QuickReplies = append(b.template.QuickReplies, reply)
+	return b
+}
+
+// AddReference appends a reference (citation).
+func (b *TemplateBuilder) AddReference(title, uri string) *TemplateBuilder {
+	b.template.References = append(b.template.References, MessageTemplateReference{Title: title, Uri: uri})
+	return b
+}
+
+// Build validates the template and returns a copy of it, so the builder can
+// keep being used. The error lists every missing or invalid field.
+func (b *TemplateBuilder) Build() (*MessageTemplate, error) {
+	t := b.template
+	if t.Buttons != nil {
+		buttons := append([]MessageTemplateButton(nil), *t.Buttons...)
+		t.Buttons = &buttons
+	}
+	if t.Columns != nil {
+		columns := append([]MessageTemplateColumn(nil), *t.Columns...)
+		t.Columns = &columns
+	}
+	t.QuickReplies = append([]QuickReply(nil), t.QuickReplies...)
+	t.References = append([]MessageTemplateReference(nil), t.References...)
+
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", t.Type, err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,246 @@
+package models
+
+// TemplateJSONSchemaID is the $id of the schema returned by
+// TemplateJSONSchema.
+const TemplateJSONSchemaID = "https://asgard-ai.com/schemas/message-template.json"
+
+// TemplateJSONSchema returns a JSON Schema (draft 2020-12) of MessageTemplate,
+// ready to be marshaled with encoding/json, for generating clients and
+// documentation in other languages. It describes the union keyed by type:
+// every template type requires the fields Validate requires of it, e.g.
+// originalContentUrl for IMAGE and table for TABLE, and actions require the
+// field of their own type. A new map is built on every call, so callers may
+// modify it.
+func TemplateJSONSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"$id":                  TemplateJSONSchemaID,
+		"title":                "MessageTemplate",
+		"type":                 "object",
+		"properties":           templateProperties(),
+		"required":             []string{"type"},
+		"additionalProperties": false,
+		"allOf": []interface{}{
+			schemaWhenType(string(MessageTemplateTypeText), schemaNonEmpty("text")),
+			schemaWhenType(string(MessageTemplateTypeImage), schemaNonEmpty("originalContentUrl")),
+			schemaWhenType(string(MessageTemplateTypeVideo), schemaNonEmpty("originalContentUrl", "previewImageUrl")),
+			schemaWhenType(string(MessageTemplateTypeAudio), schemaNonEmpty("originalContentUrl")),
+			schemaWhenType(string(MessageTemplateTypeLocation), schemaWithRequired(schemaNonEmpty("title"), "latitude", "longitude")),
+			schemaWhenType(string(MessageTemplateTypeButton), schemaWithProperty(schemaNonEmpty("text"), "buttons", schemaMinItems(1))),
+			schemaWhenType(string(MessageTemplateTypeCarousel), schemaWithProperty(schemaObject(), "columns", schemaMinItems(1))),
+			schemaWhenType(string(MessageTemplateTypeChart), schemaWithProperty(schemaObject(), "chartOptions", schemaMinItems(1))),
+			schemaWhenType(string(MessageTemplateTypeTable), schemaWithRequired(schemaObject(), "table")),
+		},
+		"$defs": templateDefs(),
+	}
+}
+
+func templateProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"type": schemaEnum(
+			string(MessageTemplateTypeText), string(MessageTemplateTypeImage), string(MessageTemplateTypeVideo),
+			string(MessageTemplateTypeAudio), string(MessageTemplateTypeLocation), string(MessageTemplateTypeButton),
+			string(MessageTemplateTypeCarousel), string(MessageTemplateTypeChart), string(MessageTemplateTypeTable),
+		),
+		"text":                 schemaString(),
+		"quickReplies":         schemaArray(schemaRef("quickReply")),
+		"originalContentUrl":   schemaString(),
+		"previewImageUrl":      schemaString(),
+		"duration":             map[string]interface{}{"type": "integer", "exclusiveMinimum": 0, "description": "Milliseconds."},
+		"title":                schemaString(),
+		"latitude":             map[string]interface{}{"type": "number", "minimum": -90, "maximum": 90},
+		"longitude":            map[string]interface{}{"type": "number", "minimum": -180, "maximum": 180},
+		"thumbnailImageUrl":    schemaString(),
+		"imageAspectRatio":     schemaRef("imageAspectRatio"),
+		"imageSize":            schemaRef("imageSize"),
+		"imageBackgroundColor": schemaString(),
+		"buttons":              schemaArray(schemaRef("button")),
+		"defaultAction":        schemaRef("action"),
+		"columns":              schemaArray(schemaRef("column")),
+		"data":                 map[string]interface{}{"description": "Chart data, in the shape the chart options expect."},
+		"chartOptions":         schemaArray(schemaRef("chartOption")),
+		"defaultChart":         schemaString(),
+		"table":                schemaRef("table"),
+		"references":           schemaArray(schemaRef("reference")),
+		"description":          map[string]interface{}{"type": "string", "deprecated": true},
+	}
+}
+
+func templateDefs() map[string]interface{} {
+	return map[string]interface{}{
+		"imageAspectRatio": schemaEnum(string(ImageAspectRatioRectangle), string(ImageAspectRatioSquare)),
+		"imageSize":        schemaEnum(string(ImageSizeCover), string(ImageSizeContain)),
+		"action": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":      schemaEnum(string(MessageTemplateActionTypeMessage), string(MessageTemplateActionTypeUri), string(MessageTemplateActionTypeEmit)),
+				"text":      schemaNullable("string"),
+				"uri":       schemaNullable("string"),
+				"eventName": schemaString(),
+				"payload":   map[string]interface{}{},
+			},
+			"required": []string{"type"},
+			"allOf": []interface{}{
+				schemaWhenType(string(MessageTemplateActionTypeMessage), schemaNonEmpty("text")),
+				schemaWhenType(string(MessageTemplateActionTypeUri), schemaNonEmpty("uri")),
+				schemaWhenType(string(MessageTemplateActionTypeEmit), schemaNonEmpty("eventName")),
+			},
+		},
+		"button": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"label":  map[string]interface{}{"type": "string", "minLength": 1},
+				"action": schemaRef("action"),
+			},
+			"required": []string{"label", "action"},
+		},
+		"quickReply": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"text":    map[string]interface{}{"type": "string", "minLength": 1},
+				"payload": map[string]interface{}{},
+				"action":  schemaRef("action"),
+			},
+			"required": []string{"text"},
+		},
+		"column": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"title":                schemaString(),
+				"text":                 schemaString(),
+				"thumbnailImageUrl":    schemaString(),
+				"imageAspectRatio":     schemaRef("imageAspectRatio"),
+				"imageSize":            schemaRef("imageSize"),
+				"imageBackgroundColor": schemaString(),
+				"buttons":              schemaArray(schemaRef("button")),
+				"defaultAction":        schemaRef("action"),
+			},
+			"required":    []string{"title", "text", "buttons"},
+			"description": "Needs a non-empty title or text.",
+			"anyOf": []interface{}{
+				schemaNonEmpty("title"),
+				schemaNonEmpty("text"),
+			},
+		},
+		"chartOption": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":  map[string]interface{}{"type": "string", "minLength": 1},
+				"title": schemaString(),
+				"spec":  map[string]interface{}{"type": "object"},
+			},
+			"required": []string{"type"},
+		},
+		"table": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"rowType": schemaEnum(string(MessageTemplateRowTypeObject), string(MessageTemplateRowTypeArray)),
+				"columns": map[string]interface{}{"type": "array", "items": schemaRef("tableColumn"), "minItems": 1},
+				"pagination": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"size": map[string]interface{}{"type": "integer", "exclusiveMinimum": 0},
+					},
+					"required": []string{"size"},
+				},
+				"data": map[string]interface{}{"type": "array"},
+			},
+			"required": []string{"rowType", "columns", "data"},
+		},
+		"tableColumn": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"header": schemaString(),
+				"key":    map[string]interface{}{"type": "string", "minLength": 1},
+				"format": schemaEnum(
+					string(MessageTemplateTableColumnFormatDate),
+					string(MessageTemplateTableColumnFormatDateTime),
+					string(MessageTemplateTableColumnFormatCurrency),
+				),
+			},
+			"required": []string{"header", "key"},
+		},
+		"reference": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"title": schemaString(),
+				"uri":   map[string]interface{}{"type": "string", "minLength": 1},
+			},
+			"required": []string{"title", "uri"},
+		},
+	}
+}
+
+// schemaWhenType applies then to objects whose type is typ.
+func schemaWhenType(typ string, then map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"if": map[string]interface{}{
+			"properties": map[string]interface{}{"type": map[string]interface{}{"const": typ}},
+			"required":   []string{"type"},
+		},
+		"then": then,
+	}
+}
+
+func schemaObject() map[string]interface{} {
+	return map[string]interface{}{"properties": map[string]interface{}{}}
+}
+
+// schemaNonEmpty requires fields to be present non-empty strings.
+func schemaNonEmpty(fields ...string) map[string]interface{} {
+	s := schemaObject()
+	for _, f := range fields {
+		schemaWithProperty(s, f, map[string]interface{}{"type": "string", "minLength": 1})
+	}
+	return schemaWithRequired(s, fields...)
+}
+
+// schemaWithProperty requires field and constrains it with schema.
+func schemaWithProperty(s map[string]interface{}, field string, schema map[string]interface{}) map[string]interface{} {
+	s["properties"].(map[string]interface{})[field] = schema
+	return schemaWithRequired(s, field)
+}
+
+func schemaWithRequired(s map[string]interface{}, fields ...string) map[string]interface{} {
+	required, _ := s["required"].([]string)
+	for _, f := range fields {
+		if !containsString(required, f) {
+			required = append(required, f)
+		}
+	}
+	s["required"] = required
+	return s
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaMinItems(n int) map[string]interface{} {
+	return map[string]interface{}{"type": "array", "minItems": n}
+}
+
+func schemaString() map[string]interface{} {
+	return map[string]interface{}{"type": "string"}
+}
+
+func schemaNullable(typ string) map[string]interface{} {
+	return map[string]interface{}{"type": []string{typ, "null"}}
+}
+
+func schemaEnum(values ...string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "enum": values}
+}
+
+func schemaArray(items map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "array", "items": items}
+}
+
+func schemaRef(def string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/$defs/" + def}
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestTemplateSchemaPropertiesMatchMessageTemplate(t *testing.T) {
+	var tags []string
+	typ := reflect.TypeOf(MessageTemplate{})
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			tags = append(tags, name)
+		}
+	}
+	var keys []string
+	for k := range TemplateJSONSchema()["properties"].(map[string]interface{}) {
+		keys = append(keys, k)
+	}
+	sort.Strings(tags)
+	sort.Strings(keys)
+	if !reflect.DeepEqual(tags, keys) {
+		t.Fatalf("schema properties %v do not match MessageTemplate fields %v", keys, tags)
+	}
+}
+
+func TestTemplateSchemaRequiredFieldsFailValidate(t *testing.T) {
+	valid := map[MessageTemplateType]string{
+		MessageTemplateTypeText:     `{"type":"TEXT","text":"hi"}`,
+		MessageTemplateTypeImage:    `{"type":"IMAGE","originalContentUrl":"https://a/i.png"}`,
+		MessageTemplateTypeVideo:    `{"type":"VIDEO","originalContentUrl":"https://a/v.mp4","previewImageUrl":"https://a/p.png"}`,
+		MessageTemplateTypeAudio:    `{"type":"AUDIO","originalContentUrl":"https://a/a.mp3"}`,
+		MessageTemplateTypeLocation: `{"type":"LOCATION","title":"here","latitude":25,"longitude":121}`,
+		MessageTemplateTypeButton:   `{"type":"BUTTON","text":"pick","buttons":[{"label":"ok","action":{"type":"MESSAGE","text":"ok"}}]}`,
+		MessageTemplateTypeCarousel: `{"type":"CAROUSEL","columns":[{"title":"t","text":"x","buttons":[]}]}`,
+		MessageTemplateTypeChart:    `{"type":"CHART","chartOptions":[{"type":"bar"}]}`,
+		MessageTemplateTypeTable:    `{"type":"TABLE","table":{"rowType":"OBJECT","columns":[{"header":"h","key":"k"}],"data":[]}}`,
+	}
+	rules := templateTypeRules(t)
+	if len(rules) != len(valid) {
+		t.Fatalf("schema has rules for %d template types, want %d", len(rules), len(valid))
+	}
+
+	for typ, doc := range valid {
+		then, ok := rules[string(typ)]
+		if !ok {
+			t.Fatalf("schema has no rule for %s", typ)
+		}
+		t.Run(string(typ), func(t *testing.T) {
+			if err := decodeTemplate(t, doc).Validate(); err != nil {
+				t.Fatalf("valid template failed Validate: %v", err)
+			}
+			if !schemaThenHolds(then, decodeObject(t, doc)) {
+				t.Fatal("valid template failed the schema")
+			}
+
+			props, _ := then["properties"].(map[string]interface{})
+			for _, field := range then["required"].([]string) {
+				variants := map[string]func(obj map[string]interface{}){
+					"missing": func(obj map[string]interface{}) { delete(obj, field) },
+				}
+				if prop, _ := props[field].(map[string]interface{}); prop != nil {
+					if _, ok := prop["minLength"]; ok {
+						variants["empty"] = func(obj map[string]interface{}) { obj[field] = "" }
+					}
+					if _, ok := prop["minItems"]; ok {
+						variants["empty"] = func(obj map[string]interface{}) { obj[field] = []interface{}{} }
+					}
+				}
+				for name, change := range variants {
+					obj := decodeObject(t, doc)
+					change(obj)
+					if schemaThenHolds(then, obj) {
+						t.Errorf("%s %s: schema accepted the template", name, field)
+					}
+					b, _ := json.Marshal(obj)
+					if err := decodeTemplate(t, string(b)).Validate(); err == nil {
+						t.Errorf("%s %s: Validate accepted the template the schema rejects", name, field)
+					}
+				}
+			}
+		})
+	}
+}
+
+// templateTypeRules returns the then-clause of each per-type rule in the
+// schema, keyed by template type.
+func templateTypeRules(t *testing.T) map[string]map[string]interface{} {
+	t.Helper()
+	rules := map[string]map[string]interface{}{}
+	for _, rule := range TemplateJSONSchema()["allOf"].([]interface{}) {
+		rule := rule.(map[string]interface{})
+		cond := rule["if"].(map[string]interface{})["properties"].(map[string]interface{})["type"].(map[string]interface{})
+		rules[cond["const"].(string)] = rule["then"].(map[string]interface{})
+	}
+	return rules
+}
+
+// schemaThenHolds evaluates the subset of JSON Schema the per-type rules use:
+// required, and minLength and minItems on their properties.
+func schemaThenHolds(then, obj map[string]interface{}) bool {
+	for _, field := range then["required"].([]string) {
+		if obj[field] == nil {
+			return false
+		}
+	}
+	props, _ := then["properties"].(map[string]interface{})
+	for field, p := range props {
+		prop := p.(map[string]interface{})
+		if n, ok := prop["minLength"].(int); ok {
+			if s, _ := obj[field].(string); len(s) < n {
+				return false
+			}
+		}
+		if n, ok := prop["minItems"].(int); ok {
+			if items, _ := obj[field].([]interface{}); len(items) < n {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func decodeObject(t *testing.T, doc string) map[string]interface{} {
+	t.Helper()
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(doc), &obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func decodeTemplate(t *testing.T, doc string) *MessageTemplate {
+	t.Helper()
+	var tmpl MessageTemplate
+	if err := json.Unmarshal([]byte(doc), &tmpl); err != nil {
+		t.Fatal(err)
+	}
+	return &tmpl
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// templateProblems collects validation errors, each prefixed with the path of
+// the offending field.
+type templateProblems []error
+
+func (p *templateProblems) add(path, format string, args ...interface{}) {
+	*p = append(*p, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+func (p *templateProblems) addErr(path string, err error) {
+	if err != nil {
+		*p = append(*p, fmt.Errorf("%s: %w", path, err))
+	}
+}
+
+// Validate checks the template and everything nested in it (buttons, columns,
+// actions, table and chart options) and returns every problem found, joined
+// into a single error, or nil when the template is well-formed.
+func (t *MessageTemplate) Validate() error {
+	var p templateProblems
+
+	switch t.Type {
+	case MessageTemplateTypeText:
+		if isBlank(t.Text) {
+			p.add("text", "required for TEXT templates")
+		}
+	case MessageTemplateTypeImage:
+		if isBlank(t.OriginalContentUrl) {
+			p.add("originalContentUrl", "required for IMAGE templates")
+		}
+	case MessageTemplateTypeVideo:
+		if isBlank(t.OriginalContentUrl) {
+			p.add("originalContentUrl", "required for VIDEO templates")
+		}
+		if isBlank(t.PreviewImageUrl) {
+			p.add("previewImageUrl", "required for VIDEO templates")
+		}
+		validateDuration(&p, t.Duration)
+	case MessageTemplateTypeAudio:
+		if isBlank(t.OriginalContentUrl) {
+			p.add("originalContentUrl", "required for AUDIO templates")
+		}
+		validateDuration(&p, t.Duration)
+	case MessageTemplateTypeLocation:
+		if isBlank(t.Title) {
+			p.add("title", "required for LOCATION templates")
+		}
+		if t.Latitude == nil {
+			p.add("latitude", "required for LOCATION templates")
+		} else if *t.Latitude < -90 || *t.Latitude > 90 {
+			p.add("latitude", "must be between -90 and 90, got %v", *t.Latitude)
+		}
+		if t.Longitude == nil {
+			p.add("longitude", "required for LOCATION templates")
+		} else if *t.Longitude < -180 || *t.Longitude > 180 {
+			p.add("longitude", "must be between -180 and 180, got %v", *t.Longitude)
+		}
+	case MessageTemplateTypeButton:
+		if isBlank(t.Text) {
+			p.add("text", "required for BUTTON templates")
+		}
+		if t.Buttons == nil || len(*t.Buttons) == 0 {
+			p.add("buttons", "BUTTON templates need at least one button")
+		}
+	case MessageTemplateTypeCarousel:
+		if t.Columns == nil || len(*t.Columns) == 0 {
+			p.add("columns", "CAROUSEL templates need at least one column")
+		}
+	case MessageTemplateTypeChart:
+		if t.ChartOptions == nil || len(*t.ChartOptions) == 0 {
+			p.add("chartOptions", "CHART templates need at least one chart option")
+		}
+	case MessageTemplateTypeTable:
+		if t.Table == nil {
+			p.add("table", "required for TABLE templates")
+		}
+	case "":
+		p.add("type", "required")
+	default:
+		p.add("type", "unknown template type %q", t.Type)
+	}
+
+	for i, qr := range t.QuickReplies {
+		if qr.Text == "" {
+			p.add(fmt.Sprintf("quickReplies[%d].text", i), "required")
+		}
+		if qr.Action != nil {
+			p.addErr(fmt.Sprintf("quickReplies[%d].action", i), qr.Action.Validate())
+		}
+	}
+	if t.Buttons != nil {
+		for i := range *t.Buttons {
+			validateButton(&p, fmt.Sprintf("buttons[%d]", i), &(*t.Buttons)[i])
+		}
+	}
+	if t.DefaultAction != nil {
+		p.addErr("defaultAction", t.DefaultAction.Validate())
+	}
+	if t.Columns != nil {
+		for i := range *t.Columns {
+			validateColumn(&p, fmt.Sprintf("columns[%d]", i), &(*t.Columns)[i])
+		}
+	}
+	if t.ChartOptions != nil {
+		for i, opt := range *t.ChartOptions {
+			if opt.Type == "" {
+				p.add(fmt.Sprintf("chartOptions[%d].type", i), "required")
+			}
+		}
+	}
+	if t.Table != nil {
+		validateTable(&p, "table", t.Table)
+	}
+	for i, ref := range t.References {
+		if ref.Uri == "" {
+			p.add(fmt.Sprintf("references[%d].uri", i), "required")
+		}
+	}
+
+	return errors.Join(p...)
+}
+
+func validateDuration(p *templateProblems, duration *int64) {
+	if duration != nil && *duration <= 0 {
+		p.add("duration", "must be positive, got %d", *duration)
+	}
+}
+
+func validateButton(p *templateProblems, path string, b *MessageTemplateButton) {
+	if b.Label == "" {
+		p.add(path+".label", "required")
+	}
+	p.addErr(path+".action", b.Action.Validate())
+}
+
+func validateColumn(p *templateProblems, path string, c *MessageTemplateColumn) {
+	if c.Title == "" && c.Text == "" {
+		p.add(path, "needs a title or text")
+	}
+	for i := range c.Buttons {
+		validateButton(p, fmt.Sprintf("%s.buttons[%d]", path, i), &c.Buttons[i])
+	}
+	if c.DefaultAction != nil {
+		p.addErr(path+".defaultAction", c.DefaultAction.Validate())
+	}
+}
+
+func validateTable(p *templateProblems, path string, t *MessageTemplateTable) {
+	switch t.RowType {
+	case MessageTemplateRowTypeObject, MessageTemplateRowTypeArray:
+	case "":
+		p.add(path+".rowType", "required")
+	default:
+		p.add(path+".rowType", "unknown row type %q", t.RowType)
+	}
+	if len(t.Columns) == 0 {
+		p.add(path+".columns", "at least one column is required")
+	}
+	for i, col := range t.Columns {
+		colPath := fmt.Sprintf("%s.columns[%d]", path, i)
+		if col.Key == "" {
+			p.add(colPath+".key", "required")
+		}
+		if col.Format != nil {
+			switch *col.Format {
+			case MessageTemplateTableColumnFormatDate, MessageTemplateTableColumnFormatDateTime, MessageTemplateTableColumnFormatCurrency:
+			default:
+				p.add(colPath+".format", "unknown format %q", *col.Format)
+			}
+		}
+	}
+	if t.Pagination != nil && t.Pagination.Size <= 0 {
+		p.add(path+".pagination.size", "must be positive, got %d", t.Pagination.Size)
+	}
+}
+
+func isBlank(s *string) bool {
+	return s == nil || *s == ""
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// DecodeToolParameter decodes the parameter of tc into T, for callers that
+// know the tool's schema. The parameter is re-encoded as JSON and decoded
+// with T's json tags; an absent parameter yields T's zero value.
+func DecodeToolParameter[T any](tc ToolCall) (T, error) {
+	v, err := redecode[T](tc.Parameter)
+	if err != nil {
+		return v, fmt.Errorf("failed to decode parameter of tool %s: %w", toolLabel(tc), err)
+	}
+	return v, nil
+}
+
+// DecodeToolResult decodes the result of a completed tool call into T, like
+// DecodeToolParameter does for its parameter.
+func DecodeToolResult[T any](fact GenericBotSseEventFactToolCallComplete) (T, error) {
+	v, err := redecode[T](fact.ToolCallResult)
+	if err != nil {
+		return v, fmt.Errorf("failed to decode result of tool %s: %w", toolLabel(fact.ToolCall), err)
+	}
+	return v, nil
+}
+
+func redecode[T any](value interface{}) (T, error) {
+	var v T
+	data, err := json.Marshal(value)
+	if err != nil {
+		return v, err
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, fmt.Errorf("does not match %s: %w", reflect.TypeOf((*T)(nil)).Elem(), err)
+	}
+	return v, nil
+}
+
+func toolLabel(tc ToolCall) string {
+	if tc.ToolsetName == "" {
+		return tc.ToolName
+	}
+	return tc.ToolsetName + "." + tc.ToolName
+}
@@ -0,0 +1,120 @@
+package models
+
+// TranscriptEntryKind identifies what a TranscriptEntry records.
+type TranscriptEntryKind string
+
+const (
+	TranscriptEntryKindMessage  TranscriptEntryKind = "MESSAGE"
+	TranscriptEntryKindToolCall TranscriptEntryKind = "TOOL_CALL"
+	TranscriptEntryKindError    TranscriptEntryKind = "ERROR"
+)
+
+// TranscriptOptions controls how a RunTranscript is assembled.
+type TranscriptOptions struct {
+	// CollapseToolCalls merges consecutive calls of the same toolset and tool
+	// into a single entry whose Count, Parameters and Results cover all of
+	// them.
+	CollapseToolCalls bool
+}
+
+// TranscriptEntry is one step of a run: a completed message, one or more
+// tool calls, or the run error.
+type TranscriptEntry struct {
+	Kind      TranscriptEntryKind `json:"kind"`
+	ProcessId string              `json:"processId,omitempty"`
+
+	Message *BufferedMessage `json:"message,omitempty"`
+
+	ToolsetName string        `json:"toolsetName,omitempty"`
+	ToolName    string        `json:"toolName,omitempty"`
+	Count       int           `json:"count,omitempty"`
+	Parameters  []interface{} `json:"parameters,omitempty"`
+	Results     []interface{} `json:"results,omitempty"`
+
+	Error *ErrorDetail `json:"error,omitempty"`
+}
+
+// RunTranscript is a readable summary of a run built from its events.
+type RunTranscript struct {
+	RequestId       string            `json:"requestId"`
+	CustomChannelId string            `json:"customChannelId"`
+	Entries         []TranscriptEntry `json:"entries"`
+	InputTokens     int64             `json:"inputTokens"`
+	OutputTokens    int64             `json:"outputTokens"`
+	TotalTokens     int64             `json:"totalTokens"`
+
+	opts TranscriptOptions
+}
+
+// NewRunTranscript creates an empty transcript that events can be added to
+// as they arrive.
+func NewRunTranscript(opts TranscriptOptions) *RunTranscript {
+	return &RunTranscript{Entries: []TranscriptEntry{}, opts: opts}
+}
+
+// BuildTranscript assembles the transcript of a complete list of events.
+func BuildTranscript(events []*GenericBotSseEvent, opts TranscriptOptions) *RunTranscript {
+	t := NewRunTranscript(opts)
+	for _, e := range events {
+		t.Add(e)
+	}
+	return t
+}
+
+// Add records event in the transcript. Events that carry no transcript
+// content, such as deltas and tool call starts, only update the run IDs.
+func (t *RunTranscript) Add(event *GenericBotSseEvent) {
+	if event == nil {
+		return
+	}
+	if t.RequestId == "" {
+		t.RequestId = event.RequestId
+	}
+	if t.CustomChannelId == "" {
+		t.CustomChannelId = event.CustomChannelId
+	}
+
+	f := event.Fact
+	switch {
+	case f.MessageComplete != nil:
+		msg := f.MessageComplete.Message
+		t.Entries = append(t.Entries, TranscriptEntry{Kind: TranscriptEntryKindMessage, Message: &msg})
+	case f.ToolCallComplete != nil:
+		t.addToolCall(f.ToolCallComplete)
+	case f.RunError != nil:
+		detail := f.RunError.Error
+		t.Entries = append(t.Entries, TranscriptEntry{
+			Kind:      TranscriptEntryKindError,
+			ProcessId: detail.Location.ProcessId,
+			Error:     &detail,
+		})
+	case f.CompletionModelUsage != nil:
+		t.InputTokens += f.CompletionModelUsage.InputTokens
+		t.OutputTokens += f.CompletionModelUsage.OutputTokens
+		t.TotalTokens += f.CompletionModelUsage.TotalTokens
+	}
+}
+
+func (t *RunTranscript) addToolCall(call *GenericBotSseEventFactToolCallComplete) {
+	if t.opts.CollapseToolCalls && len(t.Entries) > 0 {
+		last := &t.Entries[len(t.Entries)-1]
+		if last.Kind == TranscriptEntryKindToolCall &&
+			last.ToolsetName == call.ToolCall.ToolsetName &&
+			last.ToolName == call.ToolCall.ToolName {
+			last.Count++
+			last.Parameters = append(last.Parameters, call.ToolCall.Parameter)
+			last.Results = append(last.Results, call.ToolCallResult)
+			return
+		}
+	}
+
+	t.Entries = append(t.Entries, TranscriptEntry{
+		Kind:        TranscriptEntryKindToolCall,
+		ProcessId:   call.ProcessId,
+		ToolsetName: call.ToolCall.ToolsetName,
+		ToolName:    call.ToolCall.ToolName,
+		Count:       1,
+		Parameters:  []interface{}{call.ToolCall.Parameter},
+		Results:     []interface{}{call.ToolCallResult},
+	})
+}
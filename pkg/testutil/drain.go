@@ -0,0 +1,45 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/client"
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// DrainStream reads stream until it ends, ctx is done or timeout elapses,
+// and returns the events read along with the stream's error. A timeout is
+// reported as an error wrapping context.DeadlineExceeded, a done ctx as its
+// error. A non-positive timeout waits for ctx alone. The stream is closed on
+// return.
+func DrainStream(ctx context.Context, stream client.BotProviderStreamer, timeout time.Duration) ([]*models.GenericBotSseEvent, error) {
+	defer stream.Close()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Next blocks until an event arrives; closing the stream is what unblocks
+	// it when the deadline passes first.
+	var stopped atomic.Bool
+	stop := context.AfterFunc(ctx, func() {
+		stopped.Store(true)
+		stream.Close()
+	})
+	defer stop()
+
+	var events []*models.GenericBotSseEvent
+	for stream.Next() {
+		events = append(events, stream.Current())
+	}
+
+	if stopped.Load() {
+		return events, fmt.Errorf("stream not drained after %d events: %w", len(events), ctx.Err())
+	}
+	return events, stream.Err()
+}
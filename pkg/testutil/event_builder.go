@@ -0,0 +1,132 @@
+package testutil
+
+import (
+	"fmt"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// EventBuilder builds synthetic stream events that share routing fields.
+// Event IDs are assigned sequentially in the order events are built.
+type EventBuilder struct {
+	template models.GenericBotSseEvent
+	seq      int
+}
+
+// NewEventBuilder creates a builder with the same placeholder routing fields
+// as NewReplyBuilder.
+func NewEventBuilder() *EventBuilder {
+	return &EventBuilder{template: models.GenericBotSseEvent{
+		RequestId:       "request-1",
+		Namespace:       "default",
+		BotProviderName: "test-bot",
+		CustomChannelId: "channel-1",
+	}}
+}
+
+// WithRequestID sets the RequestId of the events built afterwards.
+func (b *EventBuilder) WithRequestID(id string) *EventBuilder {
+	b.template.RequestId = id
+	return b
+}
+
+// WithChannel sets the CustomChannelId of the events built afterwards.
+func (b *EventBuilder) WithChannel(id string) *EventBuilder {
+	b.template.CustomChannelId = id
+	return b
+}
+
+// RunInit builds a run init event.
+func (b *EventBuilder) RunInit() *models.GenericBotSseEvent {
+	return b.event(models.SseEventTypeRunInit, models.GenericBotSseEventFact{RunInit: &models.GenericBotSseEventFactRunInit{}})
+}
+
+// RunDone builds a run done event.
+func (b *EventBuilder) RunDone() *models.GenericBotSseEvent {
+	return b.event(models.SseEventTypeRunDone, models.GenericBotSseEventFact{RunDone: &models.GenericBotSseEventFactRunDone{}})
+}
+
+// RunError builds a run error event carrying detail.
+func (b *EventBuilder) RunError(detail models.ErrorDetail) *models.GenericBotSseEvent {
+	return b.event(models.SseEventTypeRunError, models.GenericBotSseEventFact{RunError: &models.GenericBotSseEventFactRunError{Error: detail}})
+}
+
+// ProcessStart builds a process start event. A nil task is left unset.
+func (b *EventBuilder) ProcessStart(processID string, task interface{}) *models.GenericBotSseEvent {
+	fact := &models.GenericBotSseEventFactProcessStart{ProcessId: processID}
+	if task != nil {
+		fact.Task = &task
+	}
+	return b.event(models.SseEventTypeProcessStart, models.GenericBotSseEventFact{ProcessStart: fact})
+}
+
+// ProcessComplete builds a process complete event. A nil result is left unset.
+func (b *EventBuilder) ProcessComplete(processID string, result interface{}) *models.GenericBotSseEvent {
+	fact := &models.GenericBotSseEventFactProcessComplete{ProcessId: processID}
+	if result != nil {
+		fact.TaskResult = &result
+	}
+	return b.event(models.SseEventTypeProcessComplete, models.GenericBotSseEventFact{ProcessComplete: fact})
+}
+
+// ProcessLog builds a process log event carrying line.
+func (b *EventBuilder) ProcessLog(processID, line string) *models.GenericBotSseEvent {
+	fact := &models.GenericBotSseEventFactProcessLog{ProcessId: processID, Line: line}
+	return b.event(models.SseEventTypeProcessLog, models.GenericBotSseEventFact{ProcessLog: fact})
+}
+
+// MessageStart builds a message start event for message.
+func (b *EventBuilder) MessageStart(message models.BufferedMessage) *models.GenericBotSseEvent {
+	return b.event(models.SseEventTypeMessageStart, models.GenericBotSseEventFact{MessageStart: &models.GenericBotSseEventFactMessage{Message: message}})
+}
+
+// MessageDelta builds a message delta event carrying text for messageID.
+func (b *EventBuilder) MessageDelta(messageID, text string) *models.GenericBotSseEvent {
+	message := models.BufferedMessage{MessageId: messageID, Text: text}
+	return b.event(models.SseEventTypeMessageDelta, models.GenericBotSseEventFact{MessageDelta: &models.GenericBotSseEventFactMessage{Message: message}})
+}
+
+// MessageComplete builds a message complete event for message.
+func (b *EventBuilder) MessageComplete(message models.BufferedMessage) *models.GenericBotSseEvent {
+	return b.event(models.SseEventTypeMessageComplete, models.GenericBotSseEventFact{MessageComplete: &models.GenericBotSseEventFactMessage{Message: message}})
+}
+
+// ToolCallStart builds a tool call start event.
+func (b *EventBuilder) ToolCallStart(processID string, callSeq int, call models.ToolCall) *models.GenericBotSseEvent {
+	return b.event(models.SseEventTypeToolCallStart, models.GenericBotSseEventFact{ToolCallStart: &models.GenericBotSseEventFactToolCallStart{
+		ProcessId: processID,
+		CallSeq:   callSeq,
+		ToolCall:  call,
+	}})
+}
+
+// ToolCallComplete builds a tool call complete event.
+func (b *EventBuilder) ToolCallComplete(processID string, callSeq int, call models.ToolCall, result interface{}) *models.GenericBotSseEvent {
+	return b.event(models.SseEventTypeToolCallComplete, models.GenericBotSseEventFact{ToolCallComplete: &models.GenericBotSseEventFactToolCallComplete{
+		ProcessId:      processID,
+		CallSeq:        callSeq,
+		ToolCall:       call,
+		ToolCallResult: result,
+	}})
+}
+
+// CompletionModelUsage builds a token usage event; TotalTokens is the sum of
+// input and output tokens.
+func (b *EventBuilder) CompletionModelUsage(processID, modelName string, inputTokens, outputTokens int64) *models.GenericBotSseEvent {
+	return b.event(models.SseEventTypeCompletionModelUsage, models.GenericBotSseEventFact{CompletionModelUsage: &models.GenericBotSseEventFactCompletionModelUsage{
+		ProcessId:           processID,
+		CompletionModelName: modelName,
+		InputTokens:         inputTokens,
+		OutputTokens:        outputTokens,
+		TotalTokens:         inputTokens + outputTokens,
+	}})
+}
+
+func (b *EventBuilder) event(eventType models.SseEventType, fact models.GenericBotSseEventFact) *models.GenericBotSseEvent {
+	b.seq++
+	ev := b.template
+	ev.EventType = eventType
+	ev.EventId = fmt.Sprintf("event-%d", b.seq)
+	ev.Fact = fact
+	return &ev
+}
@@ -0,0 +1,176 @@
+package testutil
+
+import (
+	"errors"
+	"fmt"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// EventMatcher checks an event against an expected type and field values.
+type EventMatcher struct {
+	eventType models.SseEventType
+	checks    []func(*models.GenericBotSseEvent) error
+}
+
+// MatchOption adds a field check to an EventMatcher.
+type MatchOption func(*EventMatcher)
+
+// MatchEvent creates a matcher for events of eventType that pass every
+// option's check.
+func MatchEvent(eventType models.SseEventType, opts ...MatchOption) *EventMatcher {
+	m := &EventMatcher{eventType: eventType}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Match returns nil when event matches, or an error describing every
+// mismatch.
+func (m *EventMatcher) Match(event *models.GenericBotSseEvent) error {
+	if event == nil {
+		return fmt.Errorf("expected %s event, got nil", m.eventType)
+	}
+	if event.EventType != m.eventType {
+		return fmt.Errorf("expected %s event, got %s", m.eventType, event.EventType)
+	}
+
+	var errs []error
+	for _, check := range m.checks {
+		if err := check(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%s event %s: %w", event.EventType, event.EventId, err)
+	}
+	return nil
+}
+
+// MatchEvents matches events against matchers one to one, in order.
+func MatchEvents(events []*models.GenericBotSseEvent, matchers ...*EventMatcher) error {
+	if len(events) != len(matchers) {
+		return fmt.Errorf("expected %d events, got %d", len(matchers), len(events))
+	}
+	var errs []error
+	for i, m := range matchers {
+		if err := m.Match(events[i]); err != nil {
+			errs = append(errs, fmt.Errorf("event %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithRequestID expects the event's RequestId.
+func WithRequestID(id string) MatchOption {
+	return withCheck("requestId", id, func(ev *models.GenericBotSseEvent) (string, bool) {
+		return ev.RequestId, true
+	})
+}
+
+// WithChannelID expects the event's CustomChannelId.
+func WithChannelID(id string) MatchOption {
+	return withCheck("customChannelId", id, func(ev *models.GenericBotSseEvent) (string, bool) {
+		return ev.CustomChannelId, true
+	})
+}
+
+// WithProcessID expects the ProcessId of a process, tool call or usage fact.
+func WithProcessID(id string) MatchOption {
+	return withCheck("processId", id, func(ev *models.GenericBotSseEvent) (string, bool) {
+		f := ev.Fact
+		switch {
+		case f.ProcessStart != nil:
+			return f.ProcessStart.ProcessId, true
+		case f.ProcessComplete != nil:
+			return f.ProcessComplete.ProcessId, true
+		case f.ProcessLog != nil:
+			return f.ProcessLog.ProcessId, true
+		case f.ToolCallStart != nil:
+			return f.ToolCallStart.ProcessId, true
+		case f.ToolCallComplete != nil:
+			return f.ToolCallComplete.ProcessId, true
+		case f.CompletionModelUsage != nil:
+			return f.CompletionModelUsage.ProcessId, true
+		}
+		return "", false
+	})
+}
+
+// WithMessageID expects the MessageId of a message fact.
+func WithMessageID(id string) MatchOption {
+	return withCheck("messageId", id, func(ev *models.GenericBotSseEvent) (string, bool) {
+		msg := eventMessage(ev)
+		if msg == nil {
+			return "", false
+		}
+		return msg.MessageId, true
+	})
+}
+
+// WithMessageText expects the Text of a message fact.
+func WithMessageText(text string) MatchOption {
+	return withCheck("text", text, func(ev *models.GenericBotSseEvent) (string, bool) {
+		msg := eventMessage(ev)
+		if msg == nil {
+			return "", false
+		}
+		return msg.Text, true
+	})
+}
+
+// WithToolName expects the ToolName of a tool call fact.
+func WithToolName(name string) MatchOption {
+	return withCheck("toolName", name, func(ev *models.GenericBotSseEvent) (string, bool) {
+		switch {
+		case ev.Fact.ToolCallStart != nil:
+			return ev.Fact.ToolCallStart.ToolCall.ToolName, true
+		case ev.Fact.ToolCallComplete != nil:
+			return ev.Fact.ToolCallComplete.ToolCall.ToolName, true
+		}
+		return "", false
+	})
+}
+
+// WithErrorCode expects the error Code of a run error fact.
+func WithErrorCode(code string) MatchOption {
+	return withCheck("error code", code, func(ev *models.GenericBotSseEvent) (string, bool) {
+		if ev.Fact.RunError == nil {
+			return "", false
+		}
+		return ev.Fact.RunError.Error.Code, true
+	})
+}
+
+// Where adds a custom check; check returns a non-nil error on mismatch.
+func Where(check func(*models.GenericBotSseEvent) error) MatchOption {
+	return func(m *EventMatcher) {
+		m.checks = append(m.checks, check)
+	}
+}
+
+func withCheck(field, want string, get func(*models.GenericBotSseEvent) (string, bool)) MatchOption {
+	return Where(func(ev *models.GenericBotSseEvent) error {
+		got, ok := get(ev)
+		if !ok {
+			return fmt.Errorf("%s: event has no such field", field)
+		}
+		if got != want {
+			return fmt.Errorf("%s: expected %q, got %q", field, want, got)
+		}
+		return nil
+	})
+}
+
+func eventMessage(ev *models.GenericBotSseEvent) *models.BufferedMessage {
+	switch {
+	case ev.Fact.MessageStart != nil:
+		return &ev.Fact.MessageStart.Message
+	case ev.Fact.MessageDelta != nil:
+		return &ev.Fact.MessageDelta.Message
+	case ev.Fact.MessageComplete != nil:
+		return &ev.Fact.MessageComplete.Message
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package testutil provides fixtures for code that consumes the SDK's models.
+package testutil
+
+import (
+	"fmt"
+
+	"go.asgard-ai.com/asgard-sdk-go/pkg/models"
+)
+
+// ReplyBuilder builds synthetic GenericBotReply values. Message IDs and Idx
+// values are assigned sequentially in the order messages are added, so
+// fixtures built the same way are always identical.
+type ReplyBuilder struct {
+	reply models.GenericBotReply
+}
+
+// NewReplyBuilder creates a builder with placeholder routing fields.
+func NewReplyBuilder() *ReplyBuilder {
+	return &ReplyBuilder{reply: models.GenericBotReply{
+		RequestId:       "request-1",
+		Namespace:       "default",
+		BotProviderName: "test-bot",
+		CustomChannelId: "channel-1",
+		Messages:        []models.BufferedMessage{},
+	}}
+}
+
+// WithRequestID sets the reply's RequestId.
+func (b *ReplyBuilder) WithRequestID(id string) *ReplyBuilder {
+	b.reply.RequestId = id
+	return b
+}
+
+// WithChannel sets the reply's CustomChannelId.
+func (b *ReplyBuilder) WithChannel(id string) *ReplyBuilder {
+	b.reply.CustomChannelId = id
+	return b
+}
+
+// WithBotProvider sets the reply's Namespace and BotProviderName.
+func (b *ReplyBuilder) WithBotProvider(namespace, name string) *ReplyBuilder {
+	b.reply.Namespace = namespace
+	b.reply.BotProviderName = name
+	return b
+}
+
+// AddTextMessage appends a plain text message.
+func (b *ReplyBuilder) AddTextMessage(text string) *ReplyBuilder {
+	msg := b.nextMessage()
+	msg.Text = text
+	b.reply.Messages = append(b.reply.Messages, msg)
+	return b
+}
+
+// AddTemplateMessage appends a message carrying template. The message Text is
+// taken from the template's Text when present.
+func (b *ReplyBuilder) AddTemplateMessage(template models.MessageTemplate) *ReplyBuilder {
+	msg := b.nextMessage()
+	if template.Text != nil {
+		msg.Text = *template.Text
+	}
+	msg.Template = &template
+	b.reply.Messages = append(b.reply.Messages, msg)
+	return b
+}
+
+// WithError sets the reply's ErrorDetail.
+func (b *ReplyBuilder) WithError(detail models.ErrorDetail) *ReplyBuilder {
+	b.reply.ErrorDetail = &detail
+	return b
+}
+
+// Build returns a copy of the reply built so far.
+func (b *ReplyBuilder) Build() *models.GenericBotReply {
+	reply := b.reply
+	reply.Messages = append([]models.BufferedMessage{}, b.reply.Messages...)
+	if b.reply.ErrorDetail != nil {
+		detail := *b.reply.ErrorDetail
+		reply.ErrorDetail = &detail
+	}
+	return &reply
+}
+
+func (b *ReplyBuilder) nextMessage() models.BufferedMessage {
+	idx := len(b.reply.Messages)
+	return models.BufferedMessage{
+		MessageId: fmt.Sprintf("message-%d", idx+1),
+		Idx:       &idx,
+	}
+}